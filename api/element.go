@@ -1,9 +1,15 @@
 package api
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"path"
 	"strings"
+	"unicode/utf16"
+
+	"github.com/sclevine/agouti/api/internal/bus"
 )
 
 type Element struct {
@@ -72,6 +78,14 @@ func (e *Element) GetAttribute(attribute string) (string, error) {
 	return value, nil
 }
 
+func (e *Element) GetProperty(property string) (string, error) {
+	var value string
+	if err := e.Send("GET", path.Join("property", property), nil, &value); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
 func (e *Element) GetCSS(property string) (string, error) {
 	var value string
 	if err := e.Send("GET", path.Join("css", property), nil, &value); err != nil {
@@ -89,13 +103,42 @@ func (e *Element) Clear() error {
 }
 
 func (e *Element) Value(text string) error {
-	splitText := strings.Split(text, "")
+	// W3C drivers expect the text as a single string, while JSON Wire Protocol
+	// drivers expect a list of UTF-16 code units, so that non-BMP characters
+	// such as emoji are sent as surrogate pairs.
+	if e.Session.Protocol() == bus.W3C {
+		request := struct {
+			Text string `json:"text"`
+		}{text}
+		return e.Send("POST", "value", request, nil)
+	}
+
 	request := struct {
-		Value []string `json:"value"`
-	}{splitText}
+		Value codeUnits `json:"value"`
+	}{codeUnits(text)}
 	return e.Send("POST", "value", request, nil)
 }
 
+// codeUnits is text that is encoded in JSON as a list of its UTF-16 code
+// units. Surrogates are escaped, as they cannot be represented in UTF-8.
+type codeUnits string
+
+func (c codeUnits) MarshalJSON() ([]byte, error) {
+	units := []string{}
+	for _, unit := range utf16.Encode([]rune(string(c))) {
+		if utf16.IsSurrogate(rune(unit)) {
+			units = append(units, fmt.Sprintf(`"\u%04x"`, unit))
+			continue
+		}
+		unitJSON, err := json.Marshal(string(rune(unit)))
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, string(unitJSON))
+	}
+	return []byte("[" + strings.Join(units, ",") + "]"), nil
+}
+
 func (e *Element) IsSelected() (bool, error) {
 	var selected bool
 	if err := e.Send("GET", "selected", nil, &selected); err != nil {
@@ -157,6 +200,63 @@ func (e *Element) GetSize() (width, height int, err error) {
 	return round(size.Width), round(size.Height), nil
 }
 
+func (e *Element) GetComputedLabel() (string, error) {
+	var label string
+	if err := e.Send("GET", "computedlabel", nil, &label); err != nil {
+		return "", err
+	}
+	return label, nil
+}
+
+func (e *Element) GetRect() (x, y, width, height int, err error) {
+	var rect struct {
+		X      float64 `json:"x"`
+		Y      float64 `json:"y"`
+		Width  float64 `json:"width"`
+		Height float64 `json:"height"`
+	}
+	if err := e.Send("GET", "rect", nil, &rect); err != nil {
+		return 0, 0, 0, 0, err
+	}
+	return round(rect.X), round(rect.Y), round(rect.Width), round(rect.Height), nil
+}
+
+// GetShadowRoot returns the shadow root attached to the element. If the
+// driver does not support the W3C shadow endpoint, the shadow root is
+// retrieved using JavaScript instead.
+func (e *Element) GetShadowRoot() (*ShadowRoot, error) {
+	var result struct {
+		ShadowRoot string `json:"shadow-6066-11e4-a52e-4f735466cecf"`
+	}
+
+	endpointErr := e.Send("GET", "shadow", nil, &result)
+	if endpointErr == nil {
+		return &ShadowRoot{result.ShadowRoot, e.Session, false}, nil
+	}
+
+	var scriptResult *struct {
+		Element    string `json:"element-6066-11e4-a52e-4f735466cecf"`
+		ShadowRoot string `json:"shadow-6066-11e4-a52e-4f735466cecf"`
+	}
+	arguments := []interface{}{map[string]string{
+		"ELEMENT":                             e.ID,
+		"element-6066-11e4-a52e-4f735466cecf": e.ID,
+	}}
+	if err := e.Session.Execute("return arguments[0].shadowRoot;", arguments, &scriptResult); err != nil {
+		return nil, endpointErr
+	}
+
+	switch {
+	case scriptResult == nil:
+		return nil, errors.New("element has no shadow root")
+	case scriptResult.ShadowRoot != "":
+		return &ShadowRoot{scriptResult.ShadowRoot, e.Session, false}, nil
+	case scriptResult.Element != "":
+		return &ShadowRoot{scriptResult.Element, e.Session, true}, nil
+	}
+	return nil, errors.New("element has no shadow root")
+}
+
 func round(number float64) int {
-	return int(number + 0.5)
+	return int(math.Floor(number + 0.5))
 }
@@ -1,11 +1,16 @@
 package api_test
 
 import (
+	"encoding/json"
 	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/sclevine/agouti/api"
+	busclient "github.com/sclevine/agouti/api/internal/bus"
 	"github.com/sclevine/agouti/api/internal/mocks"
 	. "github.com/sclevine/agouti/internal/matchers"
 )
@@ -173,6 +178,30 @@ var _ = Describe("Element", func() {
 		})
 	})
 
+	Describe("#GetProperty", func() {
+		It("should successfully send a GET request to the property/value endpoint", func() {
+			_, err := element.GetProperty("value")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("element/some-id/property/value"))
+		})
+
+		It("should return the value of the property", func() {
+			bus.SendCall.Result = `"some value"`
+			value, err := element.GetProperty("value")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal("some value"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := element.GetProperty("value")
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
 	Describe("#GetCSS", func() {
 		It("should successfully send a GET request to the css/some-property endpoint", func() {
 			_, err := element.GetCSS("some-property")
@@ -235,6 +264,37 @@ var _ = Describe("Element", func() {
 			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"value": ["t", "e", "x", "t"]}`))
 		})
 
+		It("should send CJK characters and emoji as UTF-16 code units", func() {
+			Expect(element.Value("日本😀")).To(Succeed())
+			Expect(string(bus.SendCall.BodyJSON)).To(Equal(`{"value":["日","本","\ud83d","\ude00"]}`))
+		})
+
+		Context("when the session uses the W3C protocol", func() {
+			var (
+				server      *httptest.Server
+				requestBody string
+			)
+
+			BeforeEach(func() {
+				server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+					body, _ := ioutil.ReadAll(request.Body)
+					requestBody = string(body)
+					response.Write([]byte(`{"value": null}`))
+				}))
+				client := &busclient.Client{SessionURL: server.URL, HTTPClient: http.DefaultClient, Protocol: "w3c"}
+				element = &Element{"some-id", &Session{client}}
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("should send CJK characters and emoji as a single string", func() {
+				Expect(element.Value("日本😀")).To(Succeed())
+				Expect(requestBody).To(MatchJSON(`{"text": "日本😀"}`))
+			})
+		})
+
 		Context("when the bus indicates a failure", func() {
 			It("should return an error", func() {
 				bus.SendCall.Err = errors.New("some error")
@@ -387,6 +447,115 @@ var _ = Describe("Element", func() {
 		})
 	})
 
+	Describe("#GetComputedLabel", func() {
+		It("should successfully send a GET request to the computedlabel endpoint", func() {
+			_, err := element.GetComputedLabel()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("element/some-id/computedlabel"))
+		})
+
+		It("should return the computed label of the element", func() {
+			bus.SendCall.Result = `"Close dialog"`
+			Expect(element.GetComputedLabel()).To(Equal("Close dialog"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := element.GetComputedLabel()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetRect", func() {
+		It("should successfully send a GET request to the rect endpoint", func() {
+			_, _, _, _, err := element.GetRect()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("element/some-id/rect"))
+		})
+
+		It("should return the rounded position and size of the element", func() {
+			bus.SendCall.Result = `{"x": 10.4, "y": 20.6, "width": 100, "height": 50}`
+			x, y, width, height, err := element.GetRect()
+			Expect(err).NotTo(HaveOccurred())
+			Expect([]int{x, y, width, height}).To(Equal([]int{10, 21, 100, 50}))
+		})
+
+		It("should round negative positions to the nearest integer", func() {
+			bus.SendCall.Result = `{"x": -8, "y": -120.6, "width": 100, "height": 50}`
+			x, y, width, height, err := element.GetRect()
+			Expect(err).NotTo(HaveOccurred())
+			Expect([]int{x, y, width, height}).To(Equal([]int{-8, -121, 100, 50}))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, _, _, _, err := element.GetRect()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetShadowRoot", func() {
+		var routedBus *endpointBus
+
+		BeforeEach(func() {
+			routedBus = newEndpointBus()
+			session.Bus = routedBus
+		})
+
+		It("should successfully send a GET request to the shadow endpoint", func() {
+			routedBus.Results["element/some-id/shadow"] = `{"shadow-6066-11e4-a52e-4f735466cecf": "some-shadow-id"}`
+			shadowRoot, err := element.GetShadowRoot()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routedBus.Requests).To(Equal([]string{"GET element/some-id/shadow"}))
+			Expect(shadowRoot).To(Equal(&ShadowRoot{"some-shadow-id", session, false}))
+		})
+
+		Context("when the shadow endpoint is not supported", func() {
+			BeforeEach(func() {
+				routedBus.Errs["element/some-id/shadow"] = errors.New("unknown command")
+			})
+
+			It("should retrieve the shadow root using JavaScript", func() {
+				routedBus.Results["execute"] = `{"element-6066-11e4-a52e-4f735466cecf": "some-shadow-id"}`
+				shadowRoot, err := element.GetShadowRoot()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(routedBus.Requests).To(Equal([]string{"GET element/some-id/shadow", "POST execute"}))
+				Expect(routedBus.Bodies["execute"]).To(MatchJSON(`{
+					"script": "return arguments[0].shadowRoot;",
+					"args": [{"ELEMENT": "some-id", "element-6066-11e4-a52e-4f735466cecf": "some-id"}]
+				}`))
+				Expect(shadowRoot).To(Equal(&ShadowRoot{"some-shadow-id", session, true}))
+			})
+
+			It("should accept a W3C shadow root reference from JavaScript", func() {
+				routedBus.Results["execute"] = `{"shadow-6066-11e4-a52e-4f735466cecf": "some-shadow-id"}`
+				Expect(element.GetShadowRoot()).To(Equal(&ShadowRoot{"some-shadow-id", session, false}))
+			})
+
+			Context("when the element has no shadow root", func() {
+				It("should return an error", func() {
+					routedBus.Results["execute"] = `null`
+					_, err := element.GetShadowRoot()
+					Expect(err).To(MatchError("element has no shadow root"))
+				})
+			})
+
+			Context("when the JavaScript fallback also fails", func() {
+				It("should return the original error", func() {
+					routedBus.Errs["execute"] = errors.New("some error")
+					_, err := element.GetShadowRoot()
+					Expect(err).To(MatchError("unknown command"))
+				})
+			})
+		})
+	})
+
 	Describe("#GetSize", func() {
 		It("should successfully send a GET request to the size endpoint", func() {
 			_, _, err := element.GetSize()
@@ -412,3 +581,27 @@ var _ = Describe("Element", func() {
 		})
 	})
 })
+
+type endpointBus struct {
+	Results  map[string]string
+	Errs     map[string]error
+	Bodies   map[string][]byte
+	Requests []string
+}
+
+func newEndpointBus() *endpointBus {
+	return &endpointBus{
+		Results: map[string]string{},
+		Errs:    map[string]error{},
+		Bodies:  map[string][]byte{},
+	}
+}
+
+func (b *endpointBus) Send(method, endpoint string, body, result interface{}) error {
+	b.Requests = append(b.Requests, method+" "+endpoint)
+	b.Bodies[endpoint], _ = json.Marshal(body)
+	if result != nil && b.Results[endpoint] != "" {
+		json.Unmarshal([]byte(b.Results[endpoint]), result)
+	}
+	return b.Errs[endpoint]
+}
@@ -4,17 +4,38 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 )
 
 type Client struct {
 	SessionURL string
 	HTTPClient *http.Client
+
+	// Capabilities are the capabilities returned by the remote end when
+	// the session was opened.
+	Capabilities map[string]interface{}
+
+	// Protocol is the protocol the remote end used to respond when the
+	// session was opened, either W3C or JSONWire. For a client that did not
+	// open its session, it is detected from the first successful response.
+	Protocol string
+
+	// Trace, if set, receives the method, URL, and body of each request along
+	// with the status and body of each response. Cookie values are redacted.
+	Trace io.Writer
+
+	metrics *metrics
 }
 
 func (c *Client) Send(method, endpoint string, body interface{}, result interface{}) error {
+	if c.metrics != nil {
+		defer c.metrics.record(method, endpoint, time.Now())
+	}
+
 	requestBody, err := bodyToJSON(body)
 	if err != nil {
 		return err
@@ -26,6 +47,10 @@ func (c *Client) Send(method, endpoint string, body interface{}, result interfac
 		return err
 	}
 
+	if c.Protocol == "" {
+		c.Protocol = responseProtocol(responseBody)
+	}
+
 	if result != nil {
 		bodyValue := struct{ Value interface{} }{result}
 		if err := json.Unmarshal(responseBody, &bodyValue); err != nil {
@@ -36,6 +61,22 @@ func (c *Client) Send(method, endpoint string, body interface{}, result interfac
 	return nil
 }
 
+// responseProtocol returns the protocol of a successful response, which only
+// JSON Wire Protocol remote ends send with a status.
+func responseProtocol(responseBody []byte) string {
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return ""
+	}
+	if _, ok := response["status"]; ok {
+		return JSONWire
+	}
+	if _, ok := response["value"]; ok {
+		return W3C
+	}
+	return ""
+}
+
 func bodyToJSON(body interface{}) ([]byte, error) {
 	if body == nil {
 		return nil, nil
@@ -59,7 +100,8 @@ func (c *Client) makeRequest(url, method string, body []byte) ([]byte, error) {
 
 	response, err := c.HTTPClient.Do(request)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %s", err)
+		c.trace(method, url, body, err.Error(), nil)
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer response.Body.Close()
 
@@ -68,6 +110,8 @@ func (c *Client) makeRequest(url, method string, body []byte) ([]byte, error) {
 		return nil, err
 	}
 
+	c.trace(method, url, body, response.Status, responseBody)
+
 	if response.StatusCode < 200 || response.StatusCode > 299 {
 		return nil, parseResponseError(responseBody)
 	}
@@ -75,16 +119,41 @@ func (c *Client) makeRequest(url, method string, body []byte) ([]byte, error) {
 	return responseBody, nil
 }
 
+// A ResponseError is returned when the remote end responds to a request with
+// an error. It carries the W3C error code (ex. "no such element") or the JSON
+// Wire Protocol status (ex. 7), whichever the remote end reported, so that
+// callers can distinguish errors without matching the message.
+type ResponseError struct {
+	Code    string
+	Status  int
+	Message string
+}
+
+func (e *ResponseError) Error() string {
+	return "request unsuccessful: " + e.Message
+}
+
 func parseResponseError(body []byte) error {
-	var errBody struct{ Value struct{ Message string } }
+	var errBody struct {
+		Status int
+		Value  struct {
+			Error   string
+			Message string
+		}
+	}
 	if err := json.Unmarshal(body, &errBody); err != nil {
 		return fmt.Errorf("request unsuccessful: %s", body)
 	}
 
-	var errMessage struct{ ErrorMessage string }
-	if err := json.Unmarshal([]byte(errBody.Value.Message), &errMessage); err != nil {
-		return fmt.Errorf("request unsuccessful: %s", errBody.Value.Message)
+	responseErr := &ResponseError{
+		Code:    errBody.Value.Error,
+		Status:  errBody.Status,
+		Message: errBody.Value.Message,
 	}
 
-	return fmt.Errorf("request unsuccessful: %s", errMessage.ErrorMessage)
+	var errMessage struct{ ErrorMessage string }
+	if err := json.Unmarshal([]byte(errBody.Value.Message), &errMessage); err == nil {
+		responseErr.Message = errMessage.ErrorMessage
+	}
+	return responseErr
 }
@@ -1,10 +1,12 @@
 package bus_test
 
 import (
+	"bytes"
 	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"syscall"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -110,6 +112,7 @@ var _ = Describe("Session", func() {
 				server.Close()
 				err := client.Send("GET", "some/endpoint", nil, nil)
 				Expect(err.Error()).To(MatchRegexp("request failed: .+ connection refused"))
+				Expect(errors.Is(err, syscall.ECONNREFUSED)).To(BeTrue())
 			})
 		})
 
@@ -126,6 +129,24 @@ var _ = Describe("Session", func() {
 				})
 			})
 
+			Context("when the server includes a W3C error code", func() {
+				It("should return a response error with the code", func() {
+					responseBody = `{"value": {"error": "no such element", "message": "Unable to locate element: #some-id"}}`
+					err := client.Send("GET", "some/endpoint", nil, nil)
+					Expect(err).To(MatchError("request unsuccessful: Unable to locate element: #some-id"))
+					Expect(err).To(Equal(&ResponseError{Code: "no such element", Message: "Unable to locate element: #some-id"}))
+				})
+			})
+
+			Context("when the server includes a legacy status code", func() {
+				It("should return a response error with the status", func() {
+					responseBody = `{"status": 10, "value": {"message": "{\"errorMessage\": \"Element is no longer attached\"}"}}`
+					err := client.Send("GET", "some/endpoint", nil, nil)
+					Expect(err).To(MatchError("request unsuccessful: Element is no longer attached"))
+					Expect(err).To(Equal(&ResponseError{Status: 10, Message: "Element is no longer attached"}))
+				})
+			})
+
 			Context("when the server does not have a valid message", func() {
 				It("should return an error indicating that the request failed with no details", func() {
 					responseBody = `$$$`
@@ -164,6 +185,146 @@ var _ = Describe("Session", func() {
 					Expect(err).To(MatchError("unexpected response: some unexpected response"))
 				})
 			})
+
+			Context("when the protocol is unknown", func() {
+				It("should detect the W3C protocol from a response without a status", func() {
+					Expect(client.Send("GET", "some/endpoint", nil, &result)).To(Succeed())
+					Expect(client.Protocol).To(Equal(W3C))
+				})
+
+				It("should detect the JSON Wire protocol from a response with a status", func() {
+					responseBody = `{"sessionId": "some-id", "status": 0, "value": {"some": "response value"}}`
+					Expect(client.Send("GET", "some/endpoint", nil, &result)).To(Succeed())
+					Expect(client.Protocol).To(Equal(JSONWire))
+				})
+
+				It("should not detect a protocol from an unexpected response", func() {
+					responseBody = "some unexpected response"
+					client.Send("GET", "some/endpoint", nil, &result)
+					Expect(client.Protocol).To(BeEmpty())
+				})
+			})
+
+			Context("when the protocol is known", func() {
+				It("should not change the protocol", func() {
+					client.Protocol = JSONWire
+					Expect(client.Send("GET", "some/endpoint", nil, &result)).To(Succeed())
+					Expect(client.Protocol).To(Equal(JSONWire))
+				})
+			})
+		})
+	})
+
+	Describe("tracing", func() {
+		var (
+			client *Client
+			trace  *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			trace = &bytes.Buffer{}
+			client = &Client{
+				SessionURL: server.URL + "/session/some-id",
+				HTTPClient: http.DefaultClient,
+			}
+		})
+
+		Context("when a trace writer is set", func() {
+			BeforeEach(func() {
+				client.Trace = trace
+			})
+
+			It("should write each request and response", func() {
+				responseBody = `{"value": "some value"}`
+				body := struct{ SomeValue string }{"some request value"}
+				Expect(client.Send("POST", "some/endpoint", body, nil)).To(Succeed())
+				Expect(trace.String()).To(Equal(
+					"--> POST " + server.URL + "/session/some-id/some/endpoint\n" +
+						`{"SomeValue":"some request value"}` + "\n" +
+						"<-- 200 OK\n" +
+						`{"value": "some value"}` + "\n",
+				))
+			})
+
+			It("should write unsuccessful responses", func() {
+				responseStatus = 404
+				responseBody = `{"value": {"message": "some error"}}`
+				client.Send("GET", "some/endpoint", nil, nil)
+				Expect(trace.String()).To(Equal(
+					"--> GET " + server.URL + "/session/some-id/some/endpoint\n" +
+						"<-- 404 Not Found\n" +
+						`{"value": {"message": "some error"}}` + "\n",
+				))
+			})
+
+			It("should redact cookie values in requests and responses", func() {
+				responseBody = `{"value": [{"name": "session", "value": "some-secret"}]}`
+				body := map[string]interface{}{"cookie": map[string]string{"name": "session", "value": "other-secret"}}
+				Expect(client.Send("POST", "cookie", body, nil)).To(Succeed())
+				Expect(trace.String()).To(ContainSubstring(`{"cookie":{"name":"session","value":"[REDACTED]"}}`))
+				Expect(trace.String()).To(ContainSubstring(`{"value":[{"name":"session","value":"[REDACTED]"}]}`))
+				Expect(trace.String()).NotTo(ContainSubstring("secret"))
+			})
+		})
+
+		Context("when a trace writer is not set", func() {
+			It("should not write anything", func() {
+				Expect(client.Send("GET", "some/endpoint", nil, nil)).To(Succeed())
+				Expect(trace.String()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("metrics", func() {
+		var client *Client
+
+		BeforeEach(func() {
+			client = &Client{
+				SessionURL: server.URL + "/session/some-id",
+				HTTPClient: http.DefaultClient,
+			}
+		})
+
+		Context("when metrics are enabled", func() {
+			BeforeEach(func() {
+				client.EnableMetrics()
+			})
+
+			It("should count the requests sent to each endpoint", func() {
+				Expect(client.Send("GET", "url", nil, nil)).To(Succeed())
+				Expect(client.Send("GET", "url", nil, nil)).To(Succeed())
+				Expect(client.Send("POST", "url", nil, nil)).To(Succeed())
+				metrics := client.Metrics()
+				Expect(metrics).To(HaveLen(2))
+				Expect(metrics["GET url"].Calls).To(Equal(2))
+				Expect(metrics["GET url"].Duration).To(BeNumerically(">", 0))
+				Expect(metrics["POST url"].Calls).To(Equal(1))
+			})
+
+			It("should group requests to the same endpoint for different elements and attributes", func() {
+				client.Send("GET", "element/some-id/text", nil, nil)
+				client.Send("GET", "element/other-id/text", nil, nil)
+				client.Send("GET", "element/some-id/attribute/href", nil, nil)
+				client.Send("POST", "window/some-id/size", nil, nil)
+				client.Send("GET", "element/active", nil, nil)
+				Expect(client.Metrics()).To(HaveKeyWithValue("GET element/:id/text", HaveField("Calls", 2)))
+				Expect(client.Metrics()).To(HaveKey("GET element/:id/attribute/:name"))
+				Expect(client.Metrics()).To(HaveKey("POST window/:id/size"))
+				Expect(client.Metrics()).To(HaveKey("GET element/active"))
+			})
+
+			It("should count unsuccessful requests", func() {
+				responseStatus = 500
+				client.Send("GET", "url", nil, nil)
+				Expect(client.Metrics()["GET url"].Calls).To(Equal(1))
+			})
+		})
+
+		Context("when metrics are not enabled", func() {
+			It("should return nil", func() {
+				Expect(client.Send("GET", "url", nil, nil)).To(Succeed())
+				Expect(client.Metrics()).To(BeNil())
+			})
 		})
 	})
 })
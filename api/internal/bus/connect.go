@@ -10,6 +10,12 @@ import (
 	"net/http"
 )
 
+// Protocols that a remote end may use to respond to a new session request.
+const (
+	W3C      = "w3c"
+	JSONWire = "jsonwire"
+)
+
 func Connect(url string, capabilities map[string]interface{}, httpClient *http.Client) (*Client, error) {
 	requestBody, err := capabilitiesToJSON(capabilities)
 	if err != nil {
@@ -20,13 +26,17 @@ func Connect(url string, capabilities map[string]interface{}, httpClient *http.C
 		httpClient = http.DefaultClient
 	}
 
-	sessionID, err := openSession(url, requestBody, httpClient)
+	session, err := openSession(url, requestBody, httpClient)
 	if err != nil {
 		return nil, err
 	}
 
-	sessionURL := fmt.Sprintf("%s/session/%s", url, sessionID)
-	return &Client{sessionURL, httpClient}, nil
+	return &Client{
+		SessionURL:   fmt.Sprintf("%s/session/%s", url, session.id),
+		HTTPClient:   httpClient,
+		Capabilities: session.capabilities,
+		Protocol:     session.protocol,
+	}, nil
 }
 
 func capabilitiesToJSON(capabilities map[string]interface{}) (io.Reader, error) {
@@ -44,43 +54,78 @@ func capabilitiesToJSON(capabilities map[string]interface{}) (io.Reader, error)
 	return bytes.NewReader(capabiltiesJSON), err
 }
 
-func openSession(url string, body io.Reader, httpClient *http.Client) (sessionID string, err error) {
+type openedSession struct {
+	id           string
+	capabilities map[string]interface{}
+	protocol     string
+}
+
+func openSession(url string, body io.Reader, httpClient *http.Client) (*openedSession, error) {
 	request, err := http.NewRequest("POST", fmt.Sprintf("%s/session", url), body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	request.Header.Add("Content-Type", "application/json")
 
 	response, err := httpClient.Do(request)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer response.Body.Close()
 
 	var sessionResponse struct {
 		SessionID string
-		// fallback for GeckoDriver
-		Value struct {
-			SessionID string
-		}
+		Status    *int
+		Value     json.RawMessage
 	}
 	responseBody, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if err := json.Unmarshal(responseBody, &sessionResponse); err != nil {
-		return "", err
+		return nil, err
+	}
+
+	// fallback for GeckoDriver and other W3C drivers
+	var w3cValue struct {
+		SessionID    string
+		Capabilities map[string]interface{}
+		Error        string
+		Message      string
+	}
+	json.Unmarshal(sessionResponse.Value, &w3cValue)
+
+	if sessionResponse.SessionID != "" {
+		var jsonWireCapabilities map[string]interface{}
+		json.Unmarshal(sessionResponse.Value, &jsonWireCapabilities)
+		return &openedSession{sessionResponse.SessionID, jsonWireCapabilities, JSONWire}, nil
 	}
 
-	if sessionResponse.SessionID == "" {
-		// fallback for GeckoDriver
-		if sessionResponse.Value.SessionID != "" {
-			return sessionResponse.Value.SessionID, nil
-		}
-		return "", errors.New("failed to retrieve a session ID")
+	if w3cValue.SessionID != "" {
+		return &openedSession{w3cValue.SessionID, w3cValue.Capabilities, W3C}, nil
 	}
 
-	return sessionResponse.SessionID, nil
+	var protocol string
+	if sessionResponse.Status != nil {
+		protocol = JSONWire
+	} else if w3cValue.Error != "" {
+		protocol = W3C
+	}
+	return nil, sessionError(w3cValue.Error, w3cValue.Message, protocol)
+}
+
+func sessionError(errorCode, message, protocol string) error {
+	description := "failed to retrieve a session ID"
+	if errorCode != "" {
+		description += ": " + errorCode
+	}
+	if message != "" {
+		description += ": " + message
+	}
+	if protocol != "" {
+		description += fmt.Sprintf(" (protocol: %s)", protocol)
+	}
+	return errors.New(description)
 }
@@ -155,4 +155,54 @@ var _ = Describe(".Connect", func() {
 			Expect(client.SessionURL).To(ContainSubstring("/session/primary-id"))
 		})
 	})
+
+	Context("when the response contains JSON Wire Protocol capabilities", func() {
+		It("should return a client with those capabilities", func() {
+			responseBody = `{"sessionId": "some-id", "value": {"browserName": "chrome", "version": "80"}}`
+			client, err := Connect(server.URL, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.Capabilities).To(Equal(map[string]interface{}{"browserName": "chrome", "version": "80"}))
+		})
+	})
+
+	Context("when the response contains W3C capabilities", func() {
+		It("should return a client with those capabilities", func() {
+			responseBody = `{"value": {"sessionId": "some-id", "capabilities": {"browserName": "firefox", "browserVersion": "90"}}}`
+			client, err := Connect(server.URL, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.Capabilities).To(Equal(map[string]interface{}{"browserName": "firefox", "browserVersion": "90"}))
+		})
+	})
+
+	Context("when the session is opened", func() {
+		It("should record the JSON Wire Protocol when the session ID is at the top level", func() {
+			responseBody = `{"sessionId": "some-id", "status": 0, "value": {}}`
+			client, err := Connect(server.URL, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.Protocol).To(Equal("jsonwire"))
+		})
+
+		It("should record the W3C protocol when the session ID is part of the value", func() {
+			responseBody = `{"value": {"sessionId": "some-id", "capabilities": {}}}`
+			client, err := Connect(server.URL, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(client.Protocol).To(Equal("w3c"))
+		})
+	})
+
+	Context("when a W3C remote end fails to create the session", func() {
+		It("should return an error that includes the protocol", func() {
+			responseBody = `{"value": {"error": "session not created", "message": "invalid capabilities"}}`
+			_, err := Connect(server.URL, nil, nil)
+			Expect(err).To(MatchError("failed to retrieve a session ID: session not created: invalid capabilities (protocol: w3c)"))
+		})
+	})
+
+	Context("when a JSON Wire Protocol remote end fails to create the session", func() {
+		It("should return an error that includes the protocol", func() {
+			responseBody = `{"status": 33, "value": {"message": "some message"}}`
+			_, err := Connect(server.URL, nil, nil)
+			Expect(err).To(MatchError("failed to retrieve a session ID: some message (protocol: jsonwire)"))
+		})
+	})
 })
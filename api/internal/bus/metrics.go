@@ -0,0 +1,83 @@
+package bus
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// An EndpointMetric records the number of requests sent to an endpoint and
+// the total time spent waiting for their responses.
+type EndpointMetric struct {
+	Calls    int
+	Duration time.Duration
+}
+
+type metrics struct {
+	mutex     sync.Mutex
+	endpoints map[string]EndpointMetric
+}
+
+// EnableMetrics starts recording an EndpointMetric for each endpoint that
+// the client sends requests to. Metrics are not recorded by default.
+func (c *Client) EnableMetrics() {
+	if c.metrics == nil {
+		c.metrics = &metrics{endpoints: map[string]EndpointMetric{}}
+	}
+}
+
+// Metrics returns the metrics recorded since EnableMetrics was called, keyed
+// by method and endpoint (ex. "GET element/:id/text"). Element, window, and
+// shadow root IDs, as well as attribute and property names, are replaced by
+// placeholders so that requests to the same endpoint are grouped together.
+// It returns nil if metrics are not enabled.
+func (c *Client) Metrics() map[string]EndpointMetric {
+	if c.metrics == nil {
+		return nil
+	}
+
+	c.metrics.mutex.Lock()
+	defer c.metrics.mutex.Unlock()
+	endpoints := map[string]EndpointMetric{}
+	for key, metric := range c.metrics.endpoints {
+		endpoints[key] = metric
+	}
+	return endpoints
+}
+
+func (m *metrics) record(method, endpoint string, start time.Time) {
+	duration := time.Since(start)
+	key := method + " " + endpointTemplate(endpoint)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	metric := m.endpoints[key]
+	metric.Calls++
+	metric.Duration += duration
+	m.endpoints[key] = metric
+}
+
+var endpointPlaceholders = map[string]string{
+	"element":   ":id",
+	"shadow":    ":id",
+	"window":    ":id",
+	"attribute": ":name",
+	"css":       ":name",
+	"property":  ":name",
+}
+
+func endpointTemplate(endpoint string) string {
+	segments := strings.Split(endpoint, "/")
+	for index := 1; index < len(segments); index++ {
+		placeholder, ok := endpointPlaceholders[segments[index-1]]
+		if !ok {
+			continue
+		}
+		if placeholder == ":id" && index == len(segments)-1 {
+			continue
+		}
+		segments[index] = placeholder
+		index++
+	}
+	return strings.Join(segments, "/")
+}
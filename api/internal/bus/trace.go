@@ -0,0 +1,66 @@
+package bus
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// trace writes a request and its response to the trace writer, if any.
+// Cookie values are redacted from both the request and response bodies.
+func (c *Client) trace(method, url string, requestBody []byte, status string, responseBody []byte) {
+	if c.Trace == nil {
+		return
+	}
+
+	if strings.Contains(url, "/cookie") {
+		requestBody = redactCookies(requestBody)
+		responseBody = redactCookies(responseBody)
+	}
+
+	fmt.Fprintf(c.Trace, "--> %s %s\n", method, url)
+	if len(requestBody) > 0 {
+		fmt.Fprintf(c.Trace, "%s\n", requestBody)
+	}
+	fmt.Fprintf(c.Trace, "<-- %s\n", status)
+	if len(responseBody) > 0 {
+		fmt.Fprintf(c.Trace, "%s\n", responseBody)
+	}
+}
+
+// redactCookies replaces the value of each cookie, which is any JSON object
+// with both a name and a value, in the provided JSON body.
+func redactCookies(body []byte) []byte {
+	var parsed interface{}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return body
+	}
+
+	redactedBody, err := json.Marshal(redactCookieValues(parsed))
+	if err != nil {
+		return body
+	}
+	return redactedBody
+}
+
+func redactCookieValues(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		_, hasName := value["name"]
+		_, hasValue := value["value"]
+		for key, child := range value {
+			if hasName && hasValue && key == "value" {
+				value[key] = redacted
+				continue
+			}
+			value[key] = redactCookieValues(child)
+		}
+	case []interface{}:
+		for index, child := range value {
+			value[index] = redactCookieValues(child)
+		}
+	}
+	return value
+}
@@ -1,8 +1,11 @@
 package api
 
 import (
+	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"strings"
 
@@ -25,7 +28,7 @@ func NewWithClient(sessionURL string, client *http.Client) *Session {
 	if client == nil {
 		client = http.DefaultClient
 	}
-	busClient := &bus.Client{sessionURL, client}
+	busClient := &bus.Client{SessionURL: sessionURL, HTTPClient: client}
 	return &Session{busClient}
 }
 
@@ -41,6 +44,72 @@ func OpenWithClient(url string, capabilities map[string]interface{}, client *htt
 	return &Session{busClient}, nil
 }
 
+// Send issues an arbitrary request to the provided endpoint, relative to the
+// session URL, and decodes the "value" of the response into result. It is an
+// escape hatch for vendor-specific endpoints (ex. "goog/cdp/execute") that
+// bypasses the abstractions provided by agouti and this package.
+func (s *Session) Send(method, endpoint string, body, result interface{}) error {
+	return s.Bus.Send(method, endpoint, body, result)
+}
+
+// Capabilities returns the capabilities granted by the remote end when the
+// session was opened. It returns nil if the session was not opened by this
+// client or the remote end did not report its capabilities.
+func (s *Session) Capabilities() map[string]interface{} {
+	if client, ok := s.Bus.(*bus.Client); ok {
+		return client.Capabilities
+	}
+	return nil
+}
+
+// Protocol returns the protocol that the remote end used when the session
+// was opened, either "w3c" or "jsonwire". For a session that was joined
+// rather than opened, the protocol is detected from the first successful
+// response. It returns an empty string if the protocol is unknown.
+func (s *Session) Protocol() string {
+	if client, ok := s.Bus.(*bus.Client); ok {
+		return client.Protocol
+	}
+	return ""
+}
+
+// SetTraceLogger sets a writer that receives each WebDriver request and
+// response sent by the session, with cookie values redacted. Pass nil to stop
+// tracing. It has no effect if the session was not opened by this client.
+func (s *Session) SetTraceLogger(logger io.Writer) {
+	if client, ok := s.Bus.(*bus.Client); ok {
+		client.Trace = logger
+	}
+}
+
+// An EndpointMetric records the number of requests sent to an endpoint and
+// the total time spent waiting for their responses.
+type EndpointMetric = bus.EndpointMetric
+
+// A ResponseError is returned when the WebDriver responds to a request with an
+// error. Its Code is the W3C error code (ex. "no such element") and its Status
+// is the JSON Wire Protocol status (ex. 7), whichever the WebDriver reported.
+type ResponseError = bus.ResponseError
+
+// EnableMetrics starts recording the number of requests sent by the session
+// to each endpoint and the time spent waiting for them. It has no effect if
+// the session was not opened by this client.
+func (s *Session) EnableMetrics() {
+	if client, ok := s.Bus.(*bus.Client); ok {
+		client.EnableMetrics()
+	}
+}
+
+// Metrics returns the metrics recorded since EnableMetrics was called, keyed
+// by method and endpoint (ex. "GET element/:id/text"). It returns nil if
+// metrics are not enabled.
+func (s *Session) Metrics() map[string]EndpointMetric {
+	if client, ok := s.Bus.(*bus.Client); ok {
+		return client.Metrics()
+	}
+	return nil
+}
+
 func (s *Session) Delete() error {
 	return s.Send("DELETE", "", nil, nil)
 }
@@ -127,6 +196,78 @@ func (s *Session) SetWindowByName(name string) error {
 	return s.Send("POST", "window", request, nil)
 }
 
+// GetWindowRect returns the position and size of the current window using the
+// W3C "get window rect" command.
+func (s *Session) GetWindowRect() (x, y, width, height int, err error) {
+	var rect struct {
+		X      float64 `json:"x"`
+		Y      float64 `json:"y"`
+		Width  float64 `json:"width"`
+		Height float64 `json:"height"`
+	}
+	if err := s.Send("GET", "window/rect", nil, &rect); err != nil {
+		return 0, 0, 0, 0, err
+	}
+	return round(rect.X), round(rect.Y), round(rect.Width), round(rect.Height), nil
+}
+
+// SetWindowRect moves and resizes the current window using the W3C "set
+// window rect" command.
+func (s *Session) SetWindowRect(x, y, width, height int) error {
+	request := struct {
+		X      int `json:"x"`
+		Y      int `json:"y"`
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	}{x, y, width, height}
+	return s.Send("POST", "window/rect", request, nil)
+}
+
+func (s *Session) Fullscreen() error {
+	return s.Send("POST", "window/fullscreen", struct{}{}, nil)
+}
+
+// ExecuteCDP runs the provided Chrome DevTools Protocol command with the
+// provided parameters and decodes its result into result, if non-nil. It uses
+// a ChromeDriver-specific endpoint, so other WebDrivers return an error.
+func (s *Session) ExecuteCDP(command string, params map[string]interface{}, result interface{}) error {
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	request := struct {
+		Command string                 `json:"cmd"`
+		Params  map[string]interface{} `json:"params"`
+	}{command, params}
+	return s.Send("POST", "goog/cdp/execute", request, result)
+}
+
+func (s *Session) SetNetworkConditions(offline bool, latency, downloadThroughput, uploadThroughput int) error {
+	request := struct {
+		NetworkConditions struct {
+			Offline            bool `json:"offline"`
+			Latency            int  `json:"latency"`
+			DownloadThroughput int  `json:"download_throughput"`
+			UploadThroughput   int  `json:"upload_throughput"`
+		} `json:"network_conditions"`
+	}{}
+	request.NetworkConditions.Offline = offline
+	request.NetworkConditions.Latency = latency
+	request.NetworkConditions.DownloadThroughput = downloadThroughput
+	request.NetworkConditions.UploadThroughput = uploadThroughput
+	return s.Send("POST", "chromium/network_conditions", request, nil)
+}
+
+func (s *Session) SetPermission(name, state string) error {
+	request := struct {
+		Descriptor struct {
+			Name string `json:"name"`
+		} `json:"descriptor"`
+		State string `json:"state"`
+	}{State: state}
+	request.Descriptor.Name = name
+	return s.Send("POST", "permissions", request, nil)
+}
+
 func (s *Session) DeleteWindow() error {
 	if err := s.Send("DELETE", "window", nil, nil); err != nil {
 		return err
@@ -171,6 +312,16 @@ func (s *Session) GetScreenshot() ([]byte, error) {
 	return base64.StdEncoding.DecodeString(base64Image)
 }
 
+func (s *Session) PrintPDF(options PrintOptions) ([]byte, error) {
+	var base64PDF string
+
+	if err := s.Send("POST", "print", options, &base64PDF); err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(base64PDF)
+}
+
 func (s *Session) GetURL() (string, error) {
 	var url string
 	if err := s.Send("GET", "url", nil, &url); err != nil {
@@ -263,6 +414,94 @@ func (s *Session) Execute(body string, arguments []interface{}, result interface
 	return nil
 }
 
+// ExecuteElements runs the provided script, which must return an element, an
+// array of elements, or null, and returns the elements. Element references
+// returned by both JSON Wire Protocol and W3C drivers are recognized.
+func (s *Session) ExecuteElements(body string, arguments []interface{}) ([]*Element, error) {
+	var result json.RawMessage
+	if err := s.Execute(body, arguments, &result); err != nil {
+		return nil, err
+	}
+
+	var references []elementReference
+	switch trimmed := bytes.TrimSpace(result); {
+	case len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")):
+	case trimmed[0] == '[':
+		if err := json.Unmarshal(trimmed, &references); err != nil {
+			return nil, errors.New("script did not return elements")
+		}
+	default:
+		var reference elementReference
+		if err := json.Unmarshal(trimmed, &reference); err != nil {
+			return nil, errors.New("script did not return elements")
+		}
+		references = append(references, reference)
+	}
+
+	elements := []*Element{}
+	for _, reference := range references {
+		id := reference.id()
+		if id == "" {
+			return nil, errors.New("script did not return elements")
+		}
+		elements = append(elements, &Element{id, s})
+	}
+	return elements, nil
+}
+
+// ExecuteNestedElements runs the provided script, which must return an
+// element, null, or an array whose items are themselves elements, nulls, or
+// arrays, to any depth. Each returned element is an *Element and each array
+// is an []interface{}, while null is returned as nil.
+func (s *Session) ExecuteNestedElements(body string, arguments []interface{}) (interface{}, error) {
+	var result json.RawMessage
+	if err := s.Execute(body, arguments, &result); err != nil {
+		return nil, err
+	}
+	return s.decodeNestedElements(result)
+}
+
+func (s *Session) decodeNestedElements(value json.RawMessage) (interface{}, error) {
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil, nil
+	}
+
+	if trimmed[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, errors.New("script did not return elements")
+		}
+		nested := []interface{}{}
+		for _, item := range items {
+			decoded, err := s.decodeNestedElements(item)
+			if err != nil {
+				return nil, err
+			}
+			nested = append(nested, decoded)
+		}
+		return nested, nil
+	}
+
+	var reference elementReference
+	if err := json.Unmarshal(trimmed, &reference); err != nil || reference.id() == "" {
+		return nil, errors.New("script did not return elements")
+	}
+	return &Element{reference.id(), s}, nil
+}
+
+type elementReference struct {
+	Element       string `json:"element-6066-11e4-a52e-4f735466cecf"`
+	LegacyElement string `json:"ELEMENT"`
+}
+
+func (r elementReference) id() string {
+	if r.Element != "" {
+		return r.Element
+	}
+	return r.LegacyElement
+}
+
 func (s *Session) Forward() error {
 	return s.Send("POST", "forward", nil, nil)
 }
@@ -322,6 +561,44 @@ func (s *Session) DoubleClick() error {
 	return s.Send("POST", "doubleclick", nil, nil)
 }
 
+// PointerDoubleClick double-clicks on the center of the provided element using
+// a W3C pointer action sequence. W3C drivers do not support DoubleClick.
+func (s *Session) PointerDoubleClick(element *Element) error {
+	return s.performPointerActions(element,
+		map[string]interface{}{"type": "pointerDown", "button": LeftButton},
+		map[string]interface{}{"type": "pointerUp", "button": LeftButton},
+		map[string]interface{}{"type": "pointerDown", "button": LeftButton},
+		map[string]interface{}{"type": "pointerUp", "button": LeftButton},
+	)
+}
+
+// PointerClick clicks the provided button on the center of the provided
+// element using a W3C pointer action sequence. W3C drivers do not support
+// MoveTo or Click.
+func (s *Session) PointerClick(element *Element, button Button) error {
+	return s.performPointerActions(element,
+		map[string]interface{}{"type": "pointerDown", "button": button},
+		map[string]interface{}{"type": "pointerUp", "button": button},
+	)
+}
+
+func (s *Session) performPointerActions(element *Element, buttonActions ...map[string]interface{}) error {
+	origin := map[string]string{"element-6066-11e4-a52e-4f735466cecf": element.ID}
+	pointerActions := []map[string]interface{}{
+		{"type": "pointerMove", "duration": 0, "origin": origin, "x": 0, "y": 0},
+	}
+	pointerActions = append(pointerActions, buttonActions...)
+	request := map[string]interface{}{
+		"actions": []map[string]interface{}{{
+			"type":       "pointer",
+			"id":         "mouse",
+			"parameters": map[string]string{"pointerType": "mouse"},
+			"actions":    pointerActions,
+		}},
+	}
+	return s.Send("POST", "actions", request, nil)
+}
+
 func (s *Session) Click(button Button) error {
 	request := struct {
 		Button Button `json:"button"`
@@ -478,6 +755,10 @@ func (s *Session) SetPageLoad(timeout int) error {
 	return s.Send("POST", "timeouts", request, nil)
 }
 
+func (s *Session) SetTimeouts(timeouts Timeouts) error {
+	return s.Send("POST", "timeouts", timeouts, nil)
+}
+
 func (s *Session) SetScriptTimeout(timeout int) error {
 	request := struct {
 		MS int `json:"ms"`
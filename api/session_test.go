@@ -1,11 +1,13 @@
 package api_test
 
 import (
+	"bytes"
 	"errors"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/sclevine/agouti/api"
+	busclient "github.com/sclevine/agouti/api/internal/bus"
 	"github.com/sclevine/agouti/api/internal/mocks"
 	. "github.com/sclevine/agouti/internal/matchers"
 )
@@ -21,6 +23,92 @@ var _ = Describe("Session", func() {
 		session = &Session{bus}
 	})
 
+	Describe("#Send", func() {
+		It("should successfully send a request to the provided endpoint", func() {
+			Expect(session.Send("POST", "goog/cdp/execute", map[string]string{"cmd": "some-command"}, nil)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("goog/cdp/execute"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "some-command"}`))
+		})
+
+		It("should retrieve the result", func() {
+			var result string
+			bus.SendCall.Result = `"some result"`
+			Expect(session.Send("GET", "endpoint", nil, &result)).To(Succeed())
+			Expect(result).To(Equal("some result"))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.Send("GET", "endpoint", nil, nil)).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#Capabilities", func() {
+		It("should return the capabilities granted when the session was opened", func() {
+			capabilities := map[string]interface{}{"browserName": "chrome"}
+			session = &Session{&busclient.Client{Capabilities: capabilities}}
+			Expect(session.Capabilities()).To(Equal(capabilities))
+		})
+
+		Context("when the session was not opened by the client", func() {
+			It("should return nil", func() {
+				Expect(session.Capabilities()).To(BeNil())
+			})
+		})
+	})
+
+	Describe("#Metrics", func() {
+		It("should return the metrics recorded by the client once enabled", func() {
+			client := &busclient.Client{}
+			session = &Session{client}
+			Expect(session.Metrics()).To(BeNil())
+			session.EnableMetrics()
+			Expect(session.Metrics()).To(BeEmpty())
+			Expect(session.Metrics()).NotTo(BeNil())
+		})
+
+		Context("when the session was not opened by the client", func() {
+			It("should return nil", func() {
+				session.EnableMetrics()
+				Expect(session.Metrics()).To(BeNil())
+			})
+		})
+	})
+
+	Describe("#SetTraceLogger", func() {
+		It("should set the trace writer used by the client", func() {
+			client := &busclient.Client{}
+			session = &Session{client}
+			logger := &bytes.Buffer{}
+			session.SetTraceLogger(logger)
+			Expect(client.Trace).To(BeIdenticalTo(logger))
+			session.SetTraceLogger(nil)
+			Expect(client.Trace).To(BeNil())
+		})
+
+		Context("when the session was not opened by the client", func() {
+			It("should not panic", func() {
+				Expect(func() { session.SetTraceLogger(&bytes.Buffer{}) }).NotTo(Panic())
+			})
+		})
+	})
+
+	Describe("#Protocol", func() {
+		It("should return the protocol used when the session was opened", func() {
+			session = &Session{&busclient.Client{Protocol: "w3c"}}
+			Expect(session.Protocol()).To(Equal("w3c"))
+		})
+
+		Context("when the session was not opened by the client", func() {
+			It("should return an empty string", func() {
+				Expect(session.Protocol()).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("#Delete", func() {
 		It("should successfully send a DELETE to the / endpoint", func() {
 			Expect(session.Delete()).To(Succeed())
@@ -222,6 +310,134 @@ var _ = Describe("Session", func() {
 		})
 	})
 
+	Describe("#Fullscreen", func() {
+		It("should successfully send a POST request to the window/fullscreen endpoint", func() {
+			Expect(session.Fullscreen()).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("window/fullscreen"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.Fullscreen()).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetWindowRect", func() {
+		It("should successfully send a GET to the window/rect endpoint", func() {
+			_, _, _, _, err := session.GetWindowRect()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("window/rect"))
+		})
+
+		It("should return the rounded position and size of the window", func() {
+			bus.SendCall.Result = `{"x": 10.4, "y": 20.6, "width": 640, "height": 480}`
+			x, y, width, height, err := session.GetWindowRect()
+			Expect(err).NotTo(HaveOccurred())
+			Expect([]int{x, y, width, height}).To(Equal([]int{10, 21, 640, 480}))
+		})
+
+		It("should round negative positions to the nearest integer", func() {
+			bus.SendCall.Result = `{"x": -8, "y": -8.4, "width": 1936, "height": 1056}`
+			x, y, width, height, err := session.GetWindowRect()
+			Expect(err).NotTo(HaveOccurred())
+			Expect([]int{x, y, width, height}).To(Equal([]int{-8, -8, 1936, 1056}))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, _, _, _, err := session.GetWindowRect()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#SetWindowRect", func() {
+		It("should successfully send a POST with the position and size to the window/rect endpoint", func() {
+			Expect(session.SetWindowRect(10, 20, 640, 480)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("window/rect"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"x": 10, "y": 20, "width": 640, "height": 480}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.SetWindowRect(10, 20, 640, 480)).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#ExecuteCDP", func() {
+		It("should successfully send a POST with the command and parameters to the goog/cdp/execute endpoint", func() {
+			Expect(session.ExecuteCDP("Some.command", map[string]interface{}{"some": "param"}, nil)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("goog/cdp/execute"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Some.command", "params": {"some": "param"}}`))
+		})
+
+		It("should send empty parameters when none are provided", func() {
+			Expect(session.ExecuteCDP("Some.command", nil, nil)).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cmd": "Some.command", "params": {}}`))
+		})
+
+		It("should decode the result", func() {
+			bus.SendCall.Result = `{"some": "result"}`
+			var result map[string]string
+			Expect(session.ExecuteCDP("Some.command", nil, &result)).To(Succeed())
+			Expect(result).To(Equal(map[string]string{"some": "result"}))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.ExecuteCDP("Some.command", nil, nil)).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#SetPermission", func() {
+		It("should successfully send a POST with the descriptor and state to the permissions endpoint", func() {
+			Expect(session.SetPermission("geolocation", "granted")).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("permissions"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"descriptor": {"name": "geolocation"}, "state": "granted"}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.SetPermission("geolocation", "granted")).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#SetNetworkConditions", func() {
+		It("should successfully send a POST with the conditions to the chromium/network_conditions endpoint", func() {
+			Expect(session.SetNetworkConditions(true, 100, 2000, 3000)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("chromium/network_conditions"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"network_conditions": {
+				"offline": true,
+				"latency": 100,
+				"download_throughput": 2000,
+				"upload_throughput": 3000
+			}}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.SetNetworkConditions(false, 0, 0, 0)).To(MatchError("some error"))
+			})
+		})
+	})
+
 	Describe("#GetCookies", func() {
 		It("should successfully send a GET to the cookie endpoint", func() {
 			_, err := session.GetCookies()
@@ -231,11 +447,11 @@ var _ = Describe("Session", func() {
 		})
 
 		It("should return the cookies", func() {
-			bus.SendCall.Result = `[{"name": "some-cookie"}, {"name": "some-other-cookie"}]`
+			bus.SendCall.Result = `[{"name": "some-cookie", "sameSite": "Lax"}, {"name": "some-other-cookie"}]`
 			cookies, err := session.GetCookies()
 			Expect(err).NotTo(HaveOccurred())
 			Expect(cookies).To(Equal([]*Cookie{
-				{Name: "some-cookie"},
+				{Name: "some-cookie", SameSite: "Lax"},
 				{Name: "some-other-cookie"},
 			}))
 		})
@@ -257,6 +473,11 @@ var _ = Describe("Session", func() {
 			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cookie": {"name": "some-cookie", "value": ""}}`))
 		})
 
+		It("should include the SameSite attribute when it is set", func() {
+			Expect(session.SetCookie(&Cookie{Name: "some-cookie", SameSite: "Strict"})).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"cookie": {"name": "some-cookie", "value": "", "sameSite": "Strict"}}`))
+		})
+
 		Context("when the cookie is nil", func() {
 			It("should return an error", func() {
 				Expect(session.SetCookie(nil)).To(MatchError("nil cookie is invalid"))
@@ -335,6 +556,47 @@ var _ = Describe("Session", func() {
 		})
 	})
 
+	Describe("#PrintPDF", func() {
+		It("should successfully send a POST with the print options to the print endpoint", func() {
+			_, err := session.PrintPDF(PrintOptions{Orientation: "landscape", Scale: 0.5, Background: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("print"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"orientation": "landscape", "scale": 0.5, "background": true}`))
+		})
+
+		It("should omit unset print options", func() {
+			_, err := session.PrintPDF(PrintOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{}`))
+		})
+
+		Context("when the PDF is valid base64", func() {
+			It("should return the decoded PDF", func() {
+				bus.SendCall.Result = `"c29tZS1wZGY="`
+				pdf, err := session.PrintPDF(PrintOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(pdf)).To(Equal("some-pdf"))
+			})
+		})
+
+		Context("when the PDF is not valid base64", func() {
+			It("should return an error", func() {
+				bus.SendCall.Result = `"..."`
+				_, err := session.PrintPDF(PrintOptions{})
+				Expect(err).To(MatchError("illegal base64 data at input byte 0"))
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := session.PrintPDF(PrintOptions{})
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
 	Describe("#GetURL", func() {
 		It("should successfully send a GET to the url endpoint", func() {
 			_, err := session.GetURL()
@@ -540,6 +802,110 @@ var _ = Describe("Session", func() {
 		})
 	})
 
+	Describe("#ExecuteElements", func() {
+		It("should successfully send a POST to the execute endpoint", func() {
+			bus.SendCall.Result = `[]`
+			_, err := session.ExecuteElements("some javascript code", []interface{}{1, "two"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("execute"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "some javascript code", "args": [1, "two"]}`))
+		})
+
+		It("should return a single returned element", func() {
+			bus.SendCall.Result = `{"element-6066-11e4-a52e-4f735466cecf": "some-id"}`
+			Expect(session.ExecuteElements("", nil)).To(Equal([]*Element{{ID: "some-id", Session: session}}))
+		})
+
+		It("should return each element of a returned array in order", func() {
+			bus.SendCall.Result = `[{"element-6066-11e4-a52e-4f735466cecf": "some-id"}, {"ELEMENT": "other-id"}]`
+			Expect(session.ExecuteElements("", nil)).To(Equal([]*Element{
+				{ID: "some-id", Session: session},
+				{ID: "other-id", Session: session},
+			}))
+		})
+
+		Context("when the script returns null", func() {
+			It("should return no elements", func() {
+				bus.SendCall.Result = `null`
+				Expect(session.ExecuteElements("", nil)).To(BeEmpty())
+			})
+		})
+
+		Context("when the script returns something other than elements", func() {
+			It("should return an error", func() {
+				bus.SendCall.Result = `"some text"`
+				_, err := session.ExecuteElements("", nil)
+				Expect(err).To(MatchError("script did not return elements"))
+				bus.SendCall.Result = `[{"some": "object"}]`
+				_, err = session.ExecuteElements("", nil)
+				Expect(err).To(MatchError("script did not return elements"))
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := session.ExecuteElements("", nil)
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#ExecuteNestedElements", func() {
+		It("should successfully send a POST to the execute endpoint", func() {
+			bus.SendCall.Result = `[]`
+			_, err := session.ExecuteNestedElements("some javascript code", []interface{}{1, "two"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Endpoint).To(Equal("execute"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": "some javascript code", "args": [1, "two"]}`))
+		})
+
+		It("should return elements nested in arrays at any depth", func() {
+			bus.SendCall.Result = `[
+				[{"element-6066-11e4-a52e-4f735466cecf": "first-id"}, {"ELEMENT": "second-id"}],
+				[],
+				[[{"element-6066-11e4-a52e-4f735466cecf": "third-id"}], null]
+			]`
+			Expect(session.ExecuteNestedElements("", nil)).To(Equal([]interface{}{
+				[]interface{}{&Element{ID: "first-id", Session: session}, &Element{ID: "second-id", Session: session}},
+				[]interface{}{},
+				[]interface{}{[]interface{}{&Element{ID: "third-id", Session: session}}, nil},
+			}))
+		})
+
+		It("should return a single returned element", func() {
+			bus.SendCall.Result = `{"ELEMENT": "some-id"}`
+			Expect(session.ExecuteNestedElements("", nil)).To(Equal(&Element{ID: "some-id", Session: session}))
+		})
+
+		Context("when the script returns null", func() {
+			It("should return nil", func() {
+				bus.SendCall.Result = `null`
+				Expect(session.ExecuteNestedElements("", nil)).To(BeNil())
+			})
+		})
+
+		Context("when the script returns something other than elements", func() {
+			It("should return an error", func() {
+				bus.SendCall.Result = `[["some text"]]`
+				_, err := session.ExecuteNestedElements("", nil)
+				Expect(err).To(MatchError("script did not return elements"))
+				bus.SendCall.Result = `[[{"some": "object"}]]`
+				_, err = session.ExecuteNestedElements("", nil)
+				Expect(err).To(MatchError("script did not return elements"))
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := session.ExecuteNestedElements("", nil)
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
 	Describe("#Forward", func() {
 		It("should successfully send a POST to the forward endpoint", func() {
 			Expect(session.Forward()).To(Succeed())
@@ -727,6 +1093,66 @@ var _ = Describe("Session", func() {
 		})
 	})
 
+	Describe("#PointerDoubleClick", func() {
+		It("should successfully send a POST to the actions endpoint", func() {
+			Expect(session.PointerDoubleClick(&Element{ID: "some-id"})).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+		})
+
+		It("should send a pointer action sequence that moves to the element and clicks twice", func() {
+			Expect(session.PointerDoubleClick(&Element{ID: "some-id"})).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+				"type": "pointer",
+				"id": "mouse",
+				"parameters": {"pointerType": "mouse"},
+				"actions": [
+					{"type": "pointerMove", "duration": 0, "origin": {"element-6066-11e4-a52e-4f735466cecf": "some-id"}, "x": 0, "y": 0},
+					{"type": "pointerDown", "button": 0},
+					{"type": "pointerUp", "button": 0},
+					{"type": "pointerDown", "button": 0},
+					{"type": "pointerUp", "button": 0}
+				]
+			}]}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.PointerDoubleClick(&Element{ID: "some-id"})).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#PointerClick", func() {
+		It("should successfully send a POST to the actions endpoint", func() {
+			Expect(session.PointerClick(&Element{ID: "some-id"}, RightButton)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("actions"))
+		})
+
+		It("should send a pointer action sequence that moves to the element and clicks the button", func() {
+			Expect(session.PointerClick(&Element{ID: "some-id"}, RightButton)).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"actions": [{
+				"type": "pointer",
+				"id": "mouse",
+				"parameters": {"pointerType": "mouse"},
+				"actions": [
+					{"type": "pointerMove", "duration": 0, "origin": {"element-6066-11e4-a52e-4f735466cecf": "some-id"}, "x": 0, "y": 0},
+					{"type": "pointerDown", "button": 2},
+					{"type": "pointerUp", "button": 2}
+				]
+			}]}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.PointerClick(&Element{ID: "some-id"}, RightButton)).To(MatchError("some error"))
+			})
+		})
+	})
+
 	Describe("#Click", func() {
 		It("should successfully send a POST to the click endpoint", func() {
 			Expect(session.Click(RightButton)).To(Succeed())
@@ -1065,4 +1491,25 @@ var _ = Describe("Session", func() {
 			})
 		})
 	})
+
+	Describe("#SetTimeouts", func() {
+		It("should successfully send a POST with all of the timeouts to the timeouts endpoint", func() {
+			Expect(session.SetTimeouts(Timeouts{Implicit: 1000, PageLoad: 2000, Script: 3000})).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("timeouts"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"implicit": 1000, "pageLoad": 2000, "script": 3000}`))
+		})
+
+		It("should omit unset timeouts", func() {
+			Expect(session.SetTimeouts(Timeouts{Script: 3000})).To(Succeed())
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"script": 3000}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(session.SetTimeouts(Timeouts{})).To(MatchError("some error"))
+			})
+		})
+	})
 })
@@ -0,0 +1,49 @@
+package api
+
+import "path"
+
+// A ShadowRoot refers to the shadow root of a web component. Drivers that
+// predate W3C shadow root support expose shadow roots as elements, in which
+// case Legacy is set and requests are sent to the element endpoints instead.
+type ShadowRoot struct {
+	ID      string
+	Session *Session
+	Legacy  bool
+}
+
+func (r *ShadowRoot) Send(method, endpoint string, body, result interface{}) error {
+	prefix := "shadow"
+	if r.Legacy {
+		prefix = "element"
+	}
+	return r.Session.Send(method, path.Join(prefix, r.ID, endpoint), body, result)
+}
+
+func (r *ShadowRoot) GetElement(selector Selector) (*Element, error) {
+	var result struct {
+		Element string `json:"element-6066-11e4-a52e-4f735466cecf"`
+	}
+
+	if err := r.Send("POST", "element", selector, &result); err != nil {
+		return nil, err
+	}
+
+	return &Element{result.Element, r.Session}, nil
+}
+
+func (r *ShadowRoot) GetElements(selector Selector) ([]*Element, error) {
+	var results []struct {
+		Element string `json:"element-6066-11e4-a52e-4f735466cecf"`
+	}
+
+	if err := r.Send("POST", "elements", selector, &results); err != nil {
+		return nil, err
+	}
+
+	elements := []*Element{}
+	for _, result := range results {
+		elements = append(elements, &Element{result.Element, r.Session})
+	}
+
+	return elements, nil
+}
@@ -0,0 +1,106 @@
+package api_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/api/internal/mocks"
+	. "github.com/sclevine/agouti/internal/matchers"
+)
+
+var _ = Describe("ShadowRoot", func() {
+	var (
+		bus        *mocks.Bus
+		session    *Session
+		shadowRoot *ShadowRoot
+	)
+
+	BeforeEach(func() {
+		bus = &mocks.Bus{}
+		session = &Session{bus}
+		shadowRoot = &ShadowRoot{"some-id", session, false}
+	})
+
+	Describe("#Send", func() {
+		It("should successfully send a request to the provided shadow endpoint", func() {
+			Expect(shadowRoot.Send("method", "endpoint", "body", nil)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("method"))
+			Expect(bus.SendCall.Endpoint).To(Equal("shadow/some-id/endpoint"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`"body"`))
+		})
+
+		Context("when the shadow root is a legacy element reference", func() {
+			It("should send the request to the provided element endpoint", func() {
+				shadowRoot.Legacy = true
+				Expect(shadowRoot.Send("method", "endpoint", "body", nil)).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("element/some-id/endpoint"))
+			})
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(shadowRoot.Send("method", "endpoint", "body", nil)).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetElement", func() {
+		It("should successfully send a POST to the element endpoint", func() {
+			_, err := shadowRoot.GetElement(Selector{"css selector", "#selector"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("shadow/some-id/element"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"using": "css selector", "value": "#selector"}`))
+		})
+
+		It("should return the element with the correct ID and session", func() {
+			bus.SendCall.Result = `{"element-6066-11e4-a52e-4f735466cecf": "some-element-id"}`
+			element, err := shadowRoot.GetElement(Selector{"css selector", "#selector"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(element.ID).To(Equal("some-element-id"))
+			Expect(element.Session).To(ExactlyEqual(session))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := shadowRoot.GetElement(Selector{"css selector", "#selector"})
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetElements", func() {
+		It("should successfully send a POST to the elements endpoint", func() {
+			_, err := shadowRoot.GetElements(Selector{"css selector", "#selector"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("shadow/some-id/elements"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"using": "css selector", "value": "#selector"}`))
+		})
+
+		It("should return the elements with the correct IDs and sessions", func() {
+			bus.SendCall.Result = `[
+				{"element-6066-11e4-a52e-4f735466cecf": "some-id"},
+				{"element-6066-11e4-a52e-4f735466cecf": "some-other-id"}
+			]`
+			elements, err := shadowRoot.GetElements(Selector{"css selector", "#selector"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(elements[0].ID).To(Equal("some-id"))
+			Expect(elements[0].Session).To(ExactlyEqual(session))
+			Expect(elements[1].ID).To(Equal("some-other-id"))
+			Expect(elements[1].Session).To(ExactlyEqual(session))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, err := shadowRoot.GetElements(Selector{"css selector", "#selector"})
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+})
@@ -28,6 +28,23 @@ type Cookie struct {
 
 	// Expiry is the time when the cookie expires
 	Expiry float64 `json:"expiry,omitempty"`
+
+	// SameSite is "Strict", "Lax", or "None" (default: omitted, for WebDrivers
+	// that do not support it)
+	SameSite string `json:"sameSite,omitempty"`
+}
+
+// Timeouts defines the session timeouts in milliseconds. Zero timeouts are
+// left unchanged.
+type Timeouts struct {
+	// Implicit is the time to wait for elements to be found
+	Implicit int `json:"implicit,omitempty"`
+
+	// PageLoad is the time to wait for a page to load
+	PageLoad int `json:"pageLoad,omitempty"`
+
+	// Script is the time to wait for a script to finish
+	Script int `json:"script,omitempty"`
 }
 
 type Selector struct {
@@ -42,3 +59,15 @@ const (
 	MiddleButton
 	RightButton
 )
+
+// PrintOptions defines the parameters of the W3C print command
+type PrintOptions struct {
+	// Orientation is either "portrait" or "landscape" (default: "portrait")
+	Orientation string `json:"orientation,omitempty"`
+
+	// Scale is the page scale factor between 0.1 and 2 (default: 1)
+	Scale float64 `json:"scale,omitempty"`
+
+	// Background is set to true to print background graphics (default: false)
+	Background bool `json:"background,omitempty"`
+}
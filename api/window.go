@@ -19,3 +19,34 @@ func (w *Window) SetSize(width, height int) error {
 
 	return w.Send("POST", "size", request, nil)
 }
+
+func (w *Window) GetSize() (width, height int, err error) {
+	var size struct {
+		Width  float64 `json:"width"`
+		Height float64 `json:"height"`
+	}
+	if err := w.Send("GET", "size", nil, &size); err != nil {
+		return 0, 0, err
+	}
+	return round(size.Width), round(size.Height), nil
+}
+
+func (w *Window) GetPosition() (x, y int, err error) {
+	var position struct {
+		X float64 `json:"x"`
+		Y float64 `json:"y"`
+	}
+	if err := w.Send("GET", "position", nil, &position); err != nil {
+		return 0, 0, err
+	}
+	return round(position.X), round(position.Y), nil
+}
+
+func (w *Window) SetPosition(x, y int) error {
+	request := struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}{x, y}
+
+	return w.Send("POST", "position", request, nil)
+}
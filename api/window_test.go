@@ -59,4 +59,78 @@ var _ = Describe("Window", func() {
 			})
 		})
 	})
+
+	Describe("#GetSize", func() {
+		It("should successfully send a GET request to the size endpoint", func() {
+			_, _, err := window.GetSize()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("window/some-id/size"))
+		})
+
+		It("should return the rounded size of the window", func() {
+			bus.SendCall.Result = `{"width": 640.6, "height": 480.1}`
+			width, height, err := window.GetSize()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(width).To(Equal(641))
+			Expect(height).To(Equal(480))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, _, err := window.GetSize()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#GetPosition", func() {
+		It("should successfully send a GET request to the position endpoint", func() {
+			_, _, err := window.GetPosition()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bus.SendCall.Method).To(Equal("GET"))
+			Expect(bus.SendCall.Endpoint).To(Equal("window/some-id/position"))
+		})
+
+		It("should return the rounded position of the window", func() {
+			bus.SendCall.Result = `{"x": 100.7, "y": 200.2}`
+			x, y, err := window.GetPosition()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(x).To(Equal(101))
+			Expect(y).To(Equal(200))
+		})
+
+		It("should round negative positions to the nearest integer", func() {
+			bus.SendCall.Result = `{"x": -8, "y": -7.6}`
+			x, y, err := window.GetPosition()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(x).To(Equal(-8))
+			Expect(y).To(Equal(-8))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, _, err := window.GetPosition()
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+	})
+
+	Describe("#SetPosition", func() {
+		It("should successfully send a POST request to the position endpoint", func() {
+			Expect(window.SetPosition(100, 200)).To(Succeed())
+			Expect(bus.SendCall.Method).To(Equal("POST"))
+			Expect(bus.SendCall.Endpoint).To(Equal("window/some-id/position"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"x":100,"y":200}`))
+		})
+
+		Context("when the bus indicates a failure", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(window.SetPosition(100, 200)).To(MatchError("some error"))
+			})
+		})
+	})
 })
@@ -1,6 +1,10 @@
 package agouti
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
 
 // A Capabilities instance defines the desired capabilities the WebDriver
 // should use to configure a Page.
@@ -22,6 +26,27 @@ func NewCapabilities(features ...string) Capabilities {
 	return c
 }
 
+// Chrome returns a Capabilities instance for Google Chrome, ex.
+//    agouti.Chrome().Proxy(proxyConfig)
+func Chrome() Capabilities {
+	return NewCapabilities().Browser("chrome")
+}
+
+// Firefox returns a Capabilities instance for Mozilla Firefox.
+func Firefox() Capabilities {
+	return NewCapabilities().Browser("firefox")
+}
+
+// Safari returns a Capabilities instance for Apple Safari.
+func Safari() Capabilities {
+	return NewCapabilities().Browser("safari")
+}
+
+// Edge returns a Capabilities instance for Microsoft Edge.
+func Edge() Capabilities {
+	return NewCapabilities().Browser("MicrosoftEdge")
+}
+
 // Browser sets the desired browser name.
 // Possible values:
 //    {android|chrome|firefox|htmlunit|internet explorer|iPhone|iPad|opera|safari}
@@ -70,6 +95,159 @@ func (c Capabilities) Platform(platform string) Capabilities {
 	return c
 }
 
+// SetUserAgent sets the user agent string that the browser should report.
+// Browsers configure the user agent differently, so this sets both the
+// "--user-agent" argument in chromeOptions (Chrome) and the
+// "general.useragent.override" preference in moz:firefoxOptions (Firefox).
+// Other browsers ignore this capability.
+func (c Capabilities) SetUserAgent(userAgent string) Capabilities {
+	const chromeArgPrefix = "--user-agent="
+
+	chromeOptions := nestedOptions(c, "chromeOptions")
+	chromeArgs := []interface{}{}
+	existingArgs, _ := optionList(chromeOptions["args"])
+	for _, arg := range existingArgs {
+		if argString, ok := arg.(string); !ok || !strings.HasPrefix(argString, chromeArgPrefix) {
+			chromeArgs = append(chromeArgs, arg)
+		}
+	}
+	chromeOptions["args"] = append(chromeArgs, chromeArgPrefix+userAgent)
+
+	firefoxPrefs := nestedOptions(nestedOptions(c, "moz:firefoxOptions"), "prefs")
+	firefoxPrefs["general.useragent.override"] = userAgent
+	return c
+}
+
+// DownloadDir sets the directory that Chrome saves downloaded files to and
+// disables the download prompt, so that downloads complete without user
+// interaction. Use Page.WaitForDownload to wait for a file to finish
+// downloading. Other browsers ignore this capability.
+func (c Capabilities) DownloadDir(dir string) Capabilities {
+	chromePrefs := nestedOptions(nestedOptions(c, "chromeOptions"), "prefs")
+	chromePrefs["download.default_directory"] = dir
+	chromePrefs["download.prompt_for_download"] = false
+	return c
+}
+
+// chromeDevices are the device names accepted by Capabilities.EmulateDevice.
+// They are taken from the device presets built into Chrome DevTools, which
+// ChromeDriver uses for mobileEmulation.deviceName, and are a subset of the
+// presets that ChromeDriver knows about.
+var chromeDevices = map[string]bool{
+	"iPhone SE":                true,
+	"iPhone XR":                true,
+	"iPhone 12 Pro":            true,
+	"iPhone 14 Pro Max":        true,
+	"Pixel 3 XL":               true,
+	"Pixel 5":                  true,
+	"Pixel 7":                  true,
+	"Samsung Galaxy S8+":       true,
+	"Samsung Galaxy S20 Ultra": true,
+	"Galaxy Fold":              true,
+	"iPad Mini":                true,
+	"iPad Air":                 true,
+	"iPad Pro":                 true,
+	"Surface Pro 7":            true,
+	"Surface Duo":              true,
+	"Nest Hub":                 true,
+	"Nest Hub Max":             true,
+}
+
+// EmulateDevice configures Chrome to emulate the named device (ex. "iPhone SE"
+// or "Pixel 7") when the session is created. If the device is not one of the
+// known Chrome DevTools presets, NewPage returns an error instead of creating
+// the session. It replaces any metrics set using EmulateDeviceMetrics. Other
+// browsers ignore this capability.
+func (c Capabilities) EmulateDevice(deviceName string) Capabilities {
+	nestedOptions(c, "chromeOptions")["mobileEmulation"] = map[string]interface{}{
+		"deviceName": deviceName,
+	}
+	return c
+}
+
+// EmulateDeviceMetrics configures Chrome to emulate a device with the provided
+// screen size, pixel ratio, and touch support when the session is created.
+// It replaces any device set using EmulateDevice. Other browsers ignore this
+// capability.
+func (c Capabilities) EmulateDeviceMetrics(width, height int, pixelRatio float64, touch bool) Capabilities {
+	nestedOptions(c, "chromeOptions")["mobileEmulation"] = map[string]interface{}{
+		"deviceMetrics": map[string]interface{}{
+			"width":      width,
+			"height":     height,
+			"pixelRatio": pixelRatio,
+			"touch":      touch,
+		},
+	}
+	return c
+}
+
+// validate returns an error if the capabilities request emulation of a device
+// that is not a known Chrome DevTools preset.
+func (c Capabilities) validate() error {
+	chromeOptions, _ := c["chromeOptions"].(map[string]interface{})
+	mobileEmulation, _ := chromeOptions["mobileEmulation"].(map[string]interface{})
+	deviceName, ok := mobileEmulation["deviceName"].(string)
+	if ok && !chromeDevices[deviceName] {
+		return fmt.Errorf("unknown device '%s'", deviceName)
+	}
+	return nil
+}
+
+// optionList returns the items of an option that is a list, such as args,
+// whether it was provided as a []string or decoded as a []interface{}.
+func optionList(option interface{}) ([]interface{}, bool) {
+	switch option := option.(type) {
+	case []interface{}:
+		return option, true
+	case []string:
+		list := []interface{}{}
+		for _, item := range option {
+			list = append(list, item)
+		}
+		return list, true
+	}
+	return nil, false
+}
+
+// mergeOptions returns a copy of the desired options with the provided options
+// applied on top of them. Nested options, such as prefs, are merged, and list
+// options, such as args, are combined, so that options set using Capabilities
+// methods like SetUserAgent are kept when the ChromeOptions Option is used.
+func mergeOptions(desired, options map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for key, value := range desired {
+		merged[key] = value
+	}
+
+	for key, value := range options {
+		desiredMap, desiredIsMap := merged[key].(map[string]interface{})
+		optionMap, optionIsMap := value.(map[string]interface{})
+		if desiredIsMap && optionIsMap {
+			merged[key] = mergeOptions(desiredMap, optionMap)
+			continue
+		}
+
+		desiredList, desiredIsList := optionList(merged[key])
+		optionItems, optionIsList := optionList(value)
+		if desiredIsList && optionIsList {
+			merged[key] = append(append([]interface{}{}, desiredList...), optionItems...)
+			continue
+		}
+
+		merged[key] = value
+	}
+	return merged
+}
+
+func nestedOptions(options map[string]interface{}, key string) map[string]interface{} {
+	nested, ok := options[key].(map[string]interface{})
+	if !ok {
+		nested = map[string]interface{}{}
+		options[key] = nested
+	}
+	return nested
+}
+
 // With enables the provided feature (ex. "trustAllSSLCertificates").
 func (c Capabilities) With(feature string) Capabilities {
 	c[feature] = true
@@ -87,3 +265,34 @@ func (c Capabilities) JSON() (string, error) {
 	capabilitiesJSON, err := json.Marshal(c)
 	return string(capabilitiesJSON), err
 }
+
+// A FirefoxProfileBuilder builds Firefox preferences into Capabilities.
+// Create one using FirefoxProfile.
+type FirefoxProfileBuilder struct {
+	prefs map[string]interface{}
+}
+
+// FirefoxProfile returns a builder for Firefox preferences, ex.
+//    capabilities := agouti.FirefoxProfile().SetPref("browser.download.dir", "/tmp").Capabilities()
+//    driver.NewPage(agouti.Desired(capabilities))
+func FirefoxProfile() *FirefoxProfileBuilder {
+	return &FirefoxProfileBuilder{prefs: map[string]interface{}{}}
+}
+
+// SetPref sets the Firefox preference with the provided name, as found in
+// about:config. The value should be a string, bool, or number.
+func (f *FirefoxProfileBuilder) SetPref(name string, value interface{}) *FirefoxProfileBuilder {
+	f.prefs[name] = value
+	return f
+}
+
+// Capabilities returns Firefox Capabilities with the preferences set in
+// moz:firefoxOptions.
+func (f *FirefoxProfileBuilder) Capabilities() Capabilities {
+	c := Firefox()
+	prefs := nestedOptions(nestedOptions(c, "moz:firefoxOptions"), "prefs")
+	for name, value := range f.prefs {
+		prefs[name] = value
+	}
+	return c
+}
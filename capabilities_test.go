@@ -37,6 +37,116 @@ var _ = Describe("Capabilities", func() {
 		}`))
 	})
 
+	Describe("presets", func() {
+		It("should set the browser name for each browser", func() {
+			Expect(Chrome()).To(Equal(Capabilities{"browserName": "chrome"}))
+			Expect(Firefox()).To(Equal(Capabilities{"browserName": "firefox"}))
+			Expect(Safari()).To(Equal(Capabilities{"browserName": "safari"}))
+			Expect(Edge()).To(Equal(Capabilities{"browserName": "MicrosoftEdge"}))
+		})
+
+		It("should allow further capabilities to be chained", func() {
+			Expect(Chrome().With("acceptInsecureCerts").JSON()).To(MatchJSON(`{"browserName": "chrome", "acceptInsecureCerts": true}`))
+		})
+	})
+
+	Describe("#SetUserAgent", func() {
+		It("should configure the user agent for both Chrome and Firefox", func() {
+			capabilities.SetUserAgent("some-agent")
+			Expect(capabilities.JSON()).To(MatchJSON(`{
+				"firstEnabled": true,
+				"secondEnabled": true,
+				"chromeOptions": {"args": ["--user-agent=some-agent"]},
+				"moz:firefoxOptions": {"prefs": {"general.useragent.override": "some-agent"}}
+			}`))
+		})
+
+		It("should preserve other options and replace a previous user agent", func() {
+			capabilities["chromeOptions"] = map[string]interface{}{"args": []string{"--headless", "--user-agent=old-agent"}}
+			capabilities.SetUserAgent("some-agent")
+			Expect(capabilities.JSON()).To(MatchJSON(`{
+				"firstEnabled": true,
+				"secondEnabled": true,
+				"chromeOptions": {"args": ["--headless", "--user-agent=some-agent"]},
+				"moz:firefoxOptions": {"prefs": {"general.useragent.override": "some-agent"}}
+			}`))
+		})
+
+		It("should preserve args that were decoded from JSON", func() {
+			capabilities["chromeOptions"] = map[string]interface{}{"args": []interface{}{"--headless", "--user-agent=old-agent"}}
+			capabilities.SetUserAgent("some-agent")
+			Expect(capabilities["chromeOptions"]).To(Equal(map[string]interface{}{
+				"args": []interface{}{"--headless", "--user-agent=some-agent"},
+			}))
+		})
+	})
+
+	Describe("#DownloadDir", func() {
+		It("should configure the Chrome download directory without a prompt", func() {
+			capabilities["chromeOptions"] = map[string]interface{}{"args": []string{"--headless"}}
+			capabilities.DownloadDir("/some/dir")
+			Expect(capabilities.JSON()).To(MatchJSON(`{
+				"firstEnabled": true,
+				"secondEnabled": true,
+				"chromeOptions": {
+					"args": ["--headless"],
+					"prefs": {"download.default_directory": "/some/dir", "download.prompt_for_download": false}
+				}
+			}`))
+		})
+	})
+
+	Describe("#EmulateDevice", func() {
+		It("should configure Chrome to emulate the named device", func() {
+			capabilities["chromeOptions"] = map[string]interface{}{"args": []string{"--headless"}}
+			Expect(capabilities.EmulateDevice("Pixel 7").Without("secondEnabled")).To(Equal(capabilities))
+			Expect(capabilities.JSON()).To(MatchJSON(`{
+				"firstEnabled": true,
+				"secondEnabled": false,
+				"chromeOptions": {"args": ["--headless"], "mobileEmulation": {"deviceName": "Pixel 7"}}
+			}`))
+		})
+
+		Context("when the device is unknown", func() {
+			It("should prevent a page from being created", func() {
+				_, err := NewPage("http://localhost:0", Desired(capabilities.EmulateDevice("iPhone X")))
+				Expect(err).To(MatchError("invalid capabilities: unknown device 'iPhone X'"))
+			})
+		})
+	})
+
+	Describe("#EmulateDeviceMetrics", func() {
+		It("should configure Chrome to emulate the provided device metrics", func() {
+			capabilities.EmulateDevice("iPhone SE")
+			capabilities.EmulateDeviceMetrics(360, 640, 3.0, true)
+			Expect(capabilities.JSON()).To(MatchJSON(`{
+				"firstEnabled": true,
+				"secondEnabled": true,
+				"chromeOptions": {
+					"mobileEmulation": {"deviceMetrics": {"width": 360, "height": 640, "pixelRatio": 3.0, "touch": true}}
+				}
+			}`))
+		})
+	})
+
+	Describe("FirefoxProfile", func() {
+		It("should encode the preferences into Firefox capabilities", func() {
+			profile := FirefoxProfile().SetPref("browser.download.dir", "/tmp").SetPref("browser.download.folderList", 2)
+			Expect(profile.Capabilities().JSON()).To(MatchJSON(`{
+				"browserName": "firefox",
+				"moz:firefoxOptions": {
+					"prefs": {"browser.download.dir": "/tmp", "browser.download.folderList": 2}
+				}
+			}`))
+		})
+
+		It("should return new capabilities each time", func() {
+			profile := FirefoxProfile().SetPref("some.pref", true)
+			profile.Capabilities().Browser("other")
+			Expect(profile.Capabilities()["browserName"]).To(Equal("firefox"))
+		})
+	})
+
 	Context("when the provided options cannot be converted to JSON", func() {
 		It("should return an error", func() {
 			capabilities["some-feature"] = func() {}
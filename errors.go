@@ -0,0 +1,64 @@
+package agouti
+
+import (
+	"errors"
+	"strings"
+	"syscall"
+
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
+)
+
+// isUnsupportedError returns true if the error indicates that the WebDriver
+// does not implement the requested command.
+func isUnsupportedError(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "unknown command") ||
+		strings.Contains(message, "unknown method") ||
+		strings.Contains(message, "unsupported operation")
+}
+
+// isNoAlertError returns true if the error indicates that no alert, confirm,
+// or prompt popup is currently open.
+func isNoAlertError(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "no such alert") ||
+		strings.Contains(message, "no alert open") ||
+		strings.Contains(message, "no alert present")
+}
+
+// isNetworkError returns true if the error indicates that a connection failed,
+// either between the browser and the page it loads or between agouti and the
+// WebDriver, rather than that the WebDriver rejected the command.
+func isNetworkError(err error) bool {
+	var responseErr *api.ResponseError
+	if errors.As(err, &responseErr) {
+		return strings.Contains(strings.ToLower(responseErr.Message), "net::err_")
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// IsNoSuchElement returns true if the error indicates that no element matched
+// a selection, either because the WebDriver reported a "no such element"
+// error or because a selection that refers to one or more elements matched
+// none. It inspects the error code or status reported by the WebDriver, so it
+// is the recommended way to branch on missing elements rather than matching
+// error strings.
+func IsNoSuchElement(err error) bool {
+	if errors.Is(err, element.ErrNotFound) || errors.Is(err, element.ErrNoElements) {
+		return true
+	}
+	var responseErr *api.ResponseError
+	return errors.As(err, &responseErr) &&
+		(responseErr.Code == "no such element" || responseErr.Status == 7)
+}
+
+// IsStaleElement returns true if the error indicates that a previously selected
+// element is no longer attached to the page. It inspects the error code or
+// status reported by the WebDriver, so it is the recommended way to branch on
+// stale elements rather than matching error strings.
+func IsStaleElement(err error) bool {
+	var responseErr *api.ResponseError
+	return errors.As(err, &responseErr) &&
+		(responseErr.Code == "stale element reference" || responseErr.Status == 10)
+}
@@ -0,0 +1,42 @@
+package agouti_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
+)
+
+var _ = Describe("Errors", func() {
+	Describe(".IsNoSuchElement", func() {
+		It("should return true for errors reporting that an element could not be located", func() {
+			Expect(IsNoSuchElement(fmt.Errorf("failed to select element from selection 'CSS: #x': %w", &api.ResponseError{Code: "no such element"}))).To(BeTrue())
+			Expect(IsNoSuchElement(fmt.Errorf("failed to select element from selection 'CSS: #x': %w", &api.ResponseError{Status: 7}))).To(BeTrue())
+			Expect(IsNoSuchElement(fmt.Errorf("failed to select element from selection 'CSS: #x [single]': %w", element.ErrNotFound))).To(BeTrue())
+			Expect(IsNoSuchElement(fmt.Errorf("failed to select elements from selection 'CSS: #x': %w", element.ErrNoElements))).To(BeTrue())
+		})
+
+		It("should return false for other errors", func() {
+			Expect(IsNoSuchElement(&api.ResponseError{Code: "stale element reference"})).To(BeFalse())
+			Expect(IsNoSuchElement(errors.New("request unsuccessful: no such element: some error"))).To(BeFalse())
+			Expect(IsNoSuchElement(nil)).To(BeFalse())
+		})
+	})
+
+	Describe(".IsStaleElement", func() {
+		It("should return true for errors reporting that an element is stale", func() {
+			Expect(IsStaleElement(fmt.Errorf("failed to retrieve text for selection 'CSS: #x': %w", &api.ResponseError{Code: "stale element reference"}))).To(BeTrue())
+			Expect(IsStaleElement(fmt.Errorf("failed to retrieve text for selection 'CSS: #x': %w", &api.ResponseError{Status: 10}))).To(BeTrue())
+		})
+
+		It("should return false for other errors", func() {
+			Expect(IsStaleElement(&api.ResponseError{Code: "no such element"})).To(BeFalse())
+			Expect(IsStaleElement(errors.New("request unsuccessful: stale element reference: some error"))).To(BeFalse())
+			Expect(IsStaleElement(nil)).To(BeFalse())
+		})
+	})
+})
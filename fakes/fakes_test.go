@@ -0,0 +1,136 @@
+package fakes_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/fakes"
+)
+
+type page interface {
+	Destroy() error
+	Reset() error
+	Navigate(url string) error
+	URL() (string, error)
+	Title() (string, error)
+	HTML() (string, error)
+	Screenshot(filename string) error
+	RunScript(body string, arguments map[string]interface{}, result interface{}) error
+	PopupText() (string, error)
+	EnterPopupText(text string) error
+	ConfirmPopup() error
+	CancelPopup() error
+	IsAlertPresent() (bool, error)
+	SendKeys(keys ...string) error
+	Forward() error
+	Back() error
+	Refresh() error
+	SwitchToWindow(name string) error
+	WindowCount() (int, error)
+	ReadAllLogs(logType string) ([]agouti.Log, error)
+}
+
+type selection interface {
+	String() string
+	Text() (string, error)
+	Attribute(attribute string) (string, error)
+	CSS(property string) (string, error)
+	Value() (string, error)
+	HasClass(class string) (bool, error)
+	Count() (int, error)
+	IsPresent() (bool, error)
+	Visible() (bool, error)
+	Enabled() (bool, error)
+	Selected() (bool, error)
+	Active() (bool, error)
+	Click() error
+	DoubleClick() error
+	RightClick() error
+	Clear() error
+	Fill(text string) error
+	FillAndSubmit(text string) error
+	Check() error
+	Uncheck() error
+	Select(text string) error
+	Submit() error
+	UploadFile(filename string) error
+	SendKeys(key string) error
+	SwitchToFrame() error
+	MouseToElement() error
+	WaitUntilClickable(timeout time.Duration) error
+}
+
+var (
+	_ page      = &agouti.Page{}
+	_ page      = &fakes.Page{}
+	_ selection = &agouti.Selection{}
+	_ selection = &fakes.Selection{}
+)
+
+type loginPage struct {
+	page     page
+	username selection
+	submit   selection
+}
+
+func (l *loginPage) LogIn(name string) (string, error) {
+	if err := l.page.Navigate("/login"); err != nil {
+		return "", err
+	}
+	if err := l.username.Fill(name); err != nil {
+		return "", err
+	}
+	if err := l.submit.Click(); err != nil {
+		return "", err
+	}
+	return l.page.Title()
+}
+
+var _ = Describe("Fakes", func() {
+	var (
+		page     *fakes.Page
+		username *fakes.Selection
+		submit   *fakes.Selection
+		login    *loginPage
+	)
+
+	BeforeEach(func() {
+		page = &fakes.Page{}
+		username = &fakes.Selection{}
+		submit = &fakes.Selection{}
+		login = &loginPage{page, username, submit}
+	})
+
+	It("should record calls and return the scripted values", func() {
+		page.TitleCall.ReturnTitle = "Dashboard"
+		Expect(login.LogIn("some-user")).To(Equal("Dashboard"))
+		Expect(page.NavigateCall.URL).To(Equal("/login"))
+		Expect(username.FillCall.Text).To(Equal("some-user"))
+		Expect(submit.ClickCall.Called).To(BeTrue())
+	})
+
+	It("should return the scripted errors", func() {
+		username.FillCall.Err = errors.New("some error")
+		_, err := login.LogIn("some-user")
+		Expect(err).To(MatchError("some error"))
+		Expect(submit.ClickCall.Called).To(BeFalse())
+	})
+
+	It("should decode the scripted script result", func() {
+		var result struct{ Some string }
+		page.RunScriptCall.Result = `{"some": "result"}`
+		Expect(page.RunScript("return value;", map[string]interface{}{"value": 1}, &result)).To(Succeed())
+		Expect(result.Some).To(Equal("result"))
+		Expect(page.RunScriptCall.Body).To(Equal("return value;"))
+	})
+
+	It("should return an error when the scripted script result cannot be decoded", func() {
+		var result struct{ Some string }
+		page.RunScriptCall.Result = `{"some": 1}`
+		err := page.RunScript("return value;", nil, &result)
+		Expect(err).To(MatchError(HavePrefix("failed to decode RunScriptCall.Result: ")))
+	})
+})
@@ -0,0 +1,244 @@
+// Package fakes provides scriptable fakes of agouti.Page and agouti.Selection
+// for unit testing page objects without a browser.
+//
+// The fakes do not provide finders (ex. Find, All, or FirstByXPath). The
+// finders of agouti.Page return a concrete *agouti.Selection, so no interface
+// can be satisfied by both agouti.Page and a fake that returns
+// *fakes.Selection. Page objects that should be faked must therefore be given
+// their selections, as shown below, instead of finding them on the page.
+//
+// Each fake method records its arguments and returns the values configured
+// on the corresponding Call field. For example:
+//    page := &fakes.Page{}
+//    page.TitleCall.ReturnTitle = "Dashboard"
+//    page.NavigateCall.Err = errors.New("some error")
+//
+// To use the fakes, write page objects against small interfaces that declare
+// only the methods they need. Both *agouti.Page and *fakes.Page (or
+// *agouti.Selection and *fakes.Selection) satisfy such interfaces:
+//    type navigator interface {
+//        Navigate(url string) error
+//        Title() (string, error)
+//    }
+//
+//    type LoginPage struct {
+//        page     navigator
+//        username interface{ Fill(text string) error }
+//    }
+package fakes
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sclevine/agouti"
+)
+
+// A Page is a fake agouti.Page.
+type Page struct {
+	DestroyCall struct {
+		Called bool
+		Err    error
+	}
+
+	ResetCall struct {
+		Called bool
+		Err    error
+	}
+
+	NavigateCall struct {
+		URL string
+		Err error
+	}
+
+	URLCall struct {
+		ReturnURL string
+		Err       error
+	}
+
+	TitleCall struct {
+		ReturnTitle string
+		Err         error
+	}
+
+	HTMLCall struct {
+		ReturnHTML string
+		Err        error
+	}
+
+	ScreenshotCall struct {
+		Filename string
+		Err      error
+	}
+
+	RunScriptCall struct {
+		Body      string
+		Arguments map[string]interface{}
+		Result    string
+		Err       error
+	}
+
+	PopupTextCall struct {
+		ReturnText string
+		Err        error
+	}
+
+	EnterPopupTextCall struct {
+		Text string
+		Err  error
+	}
+
+	ConfirmPopupCall struct {
+		Called bool
+		Err    error
+	}
+
+	CancelPopupCall struct {
+		Called bool
+		Err    error
+	}
+
+	IsAlertPresentCall struct {
+		ReturnPresent bool
+		Err           error
+	}
+
+	SendKeysCall struct {
+		Keys []string
+		Err  error
+	}
+
+	ForwardCall struct {
+		Called bool
+		Err    error
+	}
+
+	BackCall struct {
+		Called bool
+		Err    error
+	}
+
+	RefreshCall struct {
+		Called bool
+		Err    error
+	}
+
+	SwitchToWindowCall struct {
+		Name string
+		Err  error
+	}
+
+	WindowCountCall struct {
+		ReturnCount int
+		Err         error
+	}
+
+	ReadAllLogsCall struct {
+		LogType    string
+		ReturnLogs []agouti.Log
+		Err        error
+	}
+}
+
+func (p *Page) Destroy() error {
+	p.DestroyCall.Called = true
+	return p.DestroyCall.Err
+}
+
+func (p *Page) Reset() error {
+	p.ResetCall.Called = true
+	return p.ResetCall.Err
+}
+
+func (p *Page) Navigate(url string) error {
+	p.NavigateCall.URL = url
+	return p.NavigateCall.Err
+}
+
+func (p *Page) URL() (string, error) {
+	return p.URLCall.ReturnURL, p.URLCall.Err
+}
+
+func (p *Page) Title() (string, error) {
+	return p.TitleCall.ReturnTitle, p.TitleCall.Err
+}
+
+func (p *Page) HTML() (string, error) {
+	return p.HTMLCall.ReturnHTML, p.HTMLCall.Err
+}
+
+func (p *Page) Screenshot(filename string) error {
+	p.ScreenshotCall.Filename = filename
+	return p.ScreenshotCall.Err
+}
+
+// RunScript records the provided body and arguments and decodes the JSON in
+// RunScriptCall.Result into the provided result. An error is returned if the
+// result cannot be decoded, so that a mistyped Result does not go unnoticed.
+func (p *Page) RunScript(body string, arguments map[string]interface{}, result interface{}) error {
+	p.RunScriptCall.Body = body
+	p.RunScriptCall.Arguments = arguments
+	if result != nil && p.RunScriptCall.Result != "" {
+		if err := json.Unmarshal([]byte(p.RunScriptCall.Result), result); err != nil {
+			return fmt.Errorf("failed to decode RunScriptCall.Result: %s", err)
+		}
+	}
+	return p.RunScriptCall.Err
+}
+
+func (p *Page) PopupText() (string, error) {
+	return p.PopupTextCall.ReturnText, p.PopupTextCall.Err
+}
+
+func (p *Page) EnterPopupText(text string) error {
+	p.EnterPopupTextCall.Text = text
+	return p.EnterPopupTextCall.Err
+}
+
+func (p *Page) ConfirmPopup() error {
+	p.ConfirmPopupCall.Called = true
+	return p.ConfirmPopupCall.Err
+}
+
+func (p *Page) CancelPopup() error {
+	p.CancelPopupCall.Called = true
+	return p.CancelPopupCall.Err
+}
+
+func (p *Page) IsAlertPresent() (bool, error) {
+	return p.IsAlertPresentCall.ReturnPresent, p.IsAlertPresentCall.Err
+}
+
+func (p *Page) SendKeys(keys ...string) error {
+	p.SendKeysCall.Keys = keys
+	return p.SendKeysCall.Err
+}
+
+func (p *Page) Forward() error {
+	p.ForwardCall.Called = true
+	return p.ForwardCall.Err
+}
+
+func (p *Page) Back() error {
+	p.BackCall.Called = true
+	return p.BackCall.Err
+}
+
+func (p *Page) Refresh() error {
+	p.RefreshCall.Called = true
+	return p.RefreshCall.Err
+}
+
+func (p *Page) SwitchToWindow(name string) error {
+	p.SwitchToWindowCall.Name = name
+	return p.SwitchToWindowCall.Err
+}
+
+func (p *Page) WindowCount() (int, error) {
+	return p.WindowCountCall.ReturnCount, p.WindowCountCall.Err
+}
+
+func (p *Page) ReadAllLogs(logType string) ([]agouti.Log, error) {
+	p.ReadAllLogsCall.LogType = logType
+	return p.ReadAllLogsCall.ReturnLogs, p.ReadAllLogsCall.Err
+}
@@ -0,0 +1,269 @@
+package fakes
+
+import "time"
+
+// A Selection is a fake agouti.Selection.
+type Selection struct {
+	StringCall struct {
+		ReturnString string
+	}
+
+	TextCall struct {
+		ReturnText string
+		Err        error
+	}
+
+	AttributeCall struct {
+		Attribute   string
+		ReturnValue string
+		Err         error
+	}
+
+	CSSCall struct {
+		Property    string
+		ReturnValue string
+		Err         error
+	}
+
+	ValueCall struct {
+		ReturnValue string
+		Err         error
+	}
+
+	HasClassCall struct {
+		Class     string
+		ReturnHas bool
+		Err       error
+	}
+
+	CountCall struct {
+		ReturnCount int
+		Err         error
+	}
+
+	IsPresentCall struct {
+		ReturnPresent bool
+		Err           error
+	}
+
+	VisibleCall struct {
+		ReturnVisible bool
+		Err           error
+	}
+
+	EnabledCall struct {
+		ReturnEnabled bool
+		Err           error
+	}
+
+	SelectedCall struct {
+		ReturnSelected bool
+		Err            error
+	}
+
+	ActiveCall struct {
+		ReturnActive bool
+		Err          error
+	}
+
+	ClickCall struct {
+		Called bool
+		Err    error
+	}
+
+	DoubleClickCall struct {
+		Called bool
+		Err    error
+	}
+
+	RightClickCall struct {
+		Called bool
+		Err    error
+	}
+
+	ClearCall struct {
+		Called bool
+		Err    error
+	}
+
+	FillCall struct {
+		Text string
+		Err  error
+	}
+
+	FillAndSubmitCall struct {
+		Text string
+		Err  error
+	}
+
+	CheckCall struct {
+		Called bool
+		Err    error
+	}
+
+	UncheckCall struct {
+		Called bool
+		Err    error
+	}
+
+	SelectCall struct {
+		Text string
+		Err  error
+	}
+
+	SubmitCall struct {
+		Called bool
+		Err    error
+	}
+
+	UploadFileCall struct {
+		Filename string
+		Err      error
+	}
+
+	SendKeysCall struct {
+		Key string
+		Err error
+	}
+
+	SwitchToFrameCall struct {
+		Called bool
+		Err    error
+	}
+
+	MouseToElementCall struct {
+		Called bool
+		Err    error
+	}
+
+	WaitUntilClickableCall struct {
+		Timeout time.Duration
+		Err     error
+	}
+}
+
+func (s *Selection) String() string {
+	return s.StringCall.ReturnString
+}
+
+func (s *Selection) Text() (string, error) {
+	return s.TextCall.ReturnText, s.TextCall.Err
+}
+
+func (s *Selection) Attribute(attribute string) (string, error) {
+	s.AttributeCall.Attribute = attribute
+	return s.AttributeCall.ReturnValue, s.AttributeCall.Err
+}
+
+func (s *Selection) CSS(property string) (string, error) {
+	s.CSSCall.Property = property
+	return s.CSSCall.ReturnValue, s.CSSCall.Err
+}
+
+func (s *Selection) Value() (string, error) {
+	return s.ValueCall.ReturnValue, s.ValueCall.Err
+}
+
+func (s *Selection) HasClass(class string) (bool, error) {
+	s.HasClassCall.Class = class
+	return s.HasClassCall.ReturnHas, s.HasClassCall.Err
+}
+
+func (s *Selection) Count() (int, error) {
+	return s.CountCall.ReturnCount, s.CountCall.Err
+}
+
+func (s *Selection) IsPresent() (bool, error) {
+	return s.IsPresentCall.ReturnPresent, s.IsPresentCall.Err
+}
+
+func (s *Selection) Visible() (bool, error) {
+	return s.VisibleCall.ReturnVisible, s.VisibleCall.Err
+}
+
+func (s *Selection) Enabled() (bool, error) {
+	return s.EnabledCall.ReturnEnabled, s.EnabledCall.Err
+}
+
+func (s *Selection) Selected() (bool, error) {
+	return s.SelectedCall.ReturnSelected, s.SelectedCall.Err
+}
+
+func (s *Selection) Active() (bool, error) {
+	return s.ActiveCall.ReturnActive, s.ActiveCall.Err
+}
+
+func (s *Selection) Click() error {
+	s.ClickCall.Called = true
+	return s.ClickCall.Err
+}
+
+func (s *Selection) DoubleClick() error {
+	s.DoubleClickCall.Called = true
+	return s.DoubleClickCall.Err
+}
+
+func (s *Selection) RightClick() error {
+	s.RightClickCall.Called = true
+	return s.RightClickCall.Err
+}
+
+func (s *Selection) Clear() error {
+	s.ClearCall.Called = true
+	return s.ClearCall.Err
+}
+
+func (s *Selection) Fill(text string) error {
+	s.FillCall.Text = text
+	return s.FillCall.Err
+}
+
+func (s *Selection) FillAndSubmit(text string) error {
+	s.FillAndSubmitCall.Text = text
+	return s.FillAndSubmitCall.Err
+}
+
+func (s *Selection) Check() error {
+	s.CheckCall.Called = true
+	return s.CheckCall.Err
+}
+
+func (s *Selection) Uncheck() error {
+	s.UncheckCall.Called = true
+	return s.UncheckCall.Err
+}
+
+func (s *Selection) Select(text string) error {
+	s.SelectCall.Text = text
+	return s.SelectCall.Err
+}
+
+func (s *Selection) Submit() error {
+	s.SubmitCall.Called = true
+	return s.SubmitCall.Err
+}
+
+func (s *Selection) UploadFile(filename string) error {
+	s.UploadFileCall.Filename = filename
+	return s.UploadFileCall.Err
+}
+
+func (s *Selection) SendKeys(key string) error {
+	s.SendKeysCall.Key = key
+	return s.SendKeysCall.Err
+}
+
+func (s *Selection) SwitchToFrame() error {
+	s.SwitchToFrameCall.Called = true
+	return s.SwitchToFrameCall.Err
+}
+
+func (s *Selection) MouseToElement() error {
+	s.MouseToElementCall.Called = true
+	return s.MouseToElementCall.Err
+}
+
+func (s *Selection) WaitUntilClickable(timeout time.Duration) error {
+	s.WaitUntilClickableCall.Timeout = timeout
+	return s.WaitUntilClickableCall.Err
+}
@@ -1,22 +1,89 @@
 package agouti
 
-import "github.com/sclevine/agouti/internal/target"
+import (
+	"time"
+
+	"github.com/sclevine/agouti/internal/target"
+)
 
 func NewTestSelection(session apiSession, elements elementRepository, firstSelector string) *Selection {
 	selector := target.Selector{Type: target.CSS, Value: firstSelector, Single: true}
-	return &Selection{selectable{session, target.Selectors{selector}}, elements}
+	return &Selection{selectable{session, target.Selectors{selector}, nil}, elements}
 }
 
 func NewTestMultiSelection(session apiSession, elements elementRepository, firstSelector string) *MultiSelection {
 	selector := target.Selector{Type: target.CSS, Value: firstSelector}
-	selection := Selection{selectable{session, target.Selectors{selector}}, elements}
+	selection := Selection{selectable{session, target.Selectors{selector}, nil}, elements}
 	return &MultiSelection{selection}
 }
 
 func NewTestPage(session apiSession) *Page {
-	return &Page{selectable{session, nil}, nil}
+	return &Page{selectable: selectable{session, nil, newWaitSettings()}}
 }
 
 func NewTestConfig() *config {
 	return &config{}
 }
+
+func PollInterval(selection interface{ pollInterval() time.Duration }) time.Duration {
+	return selection.pollInterval()
+}
+
+func StabilityTimeout(selection interface{ stabilityTimeout() time.Duration }) time.Duration {
+	return selection.stabilityTimeout()
+}
+
+func StaleElementRetries(selection interface{ staleElementRetries() int }) int {
+	return selection.staleElementRetries()
+}
+
+func WithStaleElementRetries(selection *MultiSelection, retries int) *MultiSelection {
+	if selection.waits == nil {
+		selection.waits = newWaitSettings()
+	}
+	selection.waits.staleElementRetries = retries
+	return selection
+}
+
+func WithStabilityTimeout(selection *MultiSelection, timeout time.Duration) *MultiSelection {
+	selection.waits = newWaitSettings()
+	selection.waits.pollInterval = minPollInterval
+	selection.waits.stabilityTimeout = timeout
+	return selection
+}
+
+// fakeClock advances instantly whenever a waiting method sleeps, so that
+// tests of timeouts do not wait in real time.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	ready := make(chan time.Time, 1)
+	ready <- c.now
+	return ready
+}
+
+func (s *selectable) useClock(c clock) {
+	if s.waits == nil {
+		s.waits = newWaitSettings()
+	}
+	s.waits.clock = c
+}
+
+func UseFakeClock(selection interface{ useClock(clock) }) {
+	selection.useClock(&fakeClock{now: time.Unix(0, 0)})
+}
+
+func UseFakeDefaultClock() (restore func()) {
+	original := defaultWaits.clock
+	defaultWaits.clock = &fakeClock{now: time.Unix(0, 0)}
+	return func() {
+		defaultWaits.clock = original
+	}
+}
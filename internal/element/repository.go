@@ -8,6 +8,16 @@ import (
 	"github.com/sclevine/agouti/internal/target"
 )
 
+var (
+	// ErrNoElements is returned when a selection that requires at least one
+	// element matches none.
+	ErrNoElements = errors.New("no elements found")
+
+	// ErrNotFound is returned when a selector that refers to exactly one
+	// element matches none.
+	ErrNotFound = errors.New("element not found")
+)
+
 type Repository struct {
 	Client    Client
 	Selectors target.Selectors
@@ -25,6 +35,7 @@ type Element interface {
 	GetName() (string, error)
 	GetAttribute(attribute string) (string, error)
 	GetCSS(property string) (string, error)
+	GetProperty(property string) (string, error)
 	IsSelected() (bool, error)
 	IsDisplayed() (bool, error)
 	IsEnabled() (bool, error)
@@ -34,6 +45,10 @@ type Element interface {
 	Value(text string) error
 	Submit() error
 	GetLocation() (x, y int, err error)
+	GetSize() (width, height int, err error)
+	GetRect() (x, y, width, height int, err error)
+	GetComputedLabel() (string, error)
+	GetShadowRoot() (*api.ShadowRoot, error)
 }
 
 func (e *Repository) GetAtLeastOne() ([]Element, error) {
@@ -41,16 +56,26 @@ func (e *Repository) GetAtLeastOne() ([]Element, error) {
 	if err != nil {
 		return nil, err
 	}
+	return atLeastOne(elements)
+}
 
-	if len(elements) == 0 {
-		return nil, errors.New("no elements found")
+func (e *Repository) GetExactlyOne() (Element, error) {
+	elements, err := e.Get()
+	if err != nil {
+		return nil, err
 	}
+	return exactlyOne(elements)
+}
 
+func atLeastOne(elements []Element) ([]Element, error) {
+	if len(elements) == 0 {
+		return nil, ErrNoElements
+	}
 	return elements, nil
 }
 
-func (e *Repository) GetExactlyOne() (Element, error) {
-	elements, err := e.GetAtLeastOne()
+func exactlyOne(elements []Element) (Element, error) {
+	elements, err := atLeastOne(elements)
 	if err != nil {
 		return nil, err
 	}
@@ -67,15 +92,50 @@ func (e *Repository) Get() ([]Element, error) {
 		return nil, errors.New("empty selection")
 	}
 
+	for _, selector := range e.Selectors {
+		if err := selector.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if e.Selectors[0].Type == target.Script {
+		return nil, errors.New("elements returned by a script cannot be selected again")
+	}
+
+	if e.Selectors[0].Type == target.ShadowRoot {
+		return nil, errors.New("shadow root selection requires a host element")
+	}
+
+	if e.Selectors[len(e.Selectors)-1].Type == target.ShadowRoot {
+		return nil, errors.New("shadow root selection must be followed by a selector")
+	}
+
 	lastElements, err := retrieveElements(e.Client, e.Selectors[0])
 	if err != nil {
 		return nil, err
 	}
 
+	var shadowRoots []Client
 	for _, selector := range e.Selectors[1:] {
+		if selector.Type == target.ShadowRoot {
+			shadowRoots, err = retrieveShadowRoots(lastElements)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		parents := shadowRoots
+		if parents == nil {
+			for _, element := range lastElements {
+				parents = append(parents, element)
+			}
+		}
+		shadowRoots = nil
+
 		elements := []Element{}
-		for _, element := range lastElements {
-			subElements, err := retrieveElements(element, selector)
+		for _, parent := range parents {
+			subElements, err := retrieveElements(parent, selector)
 			if err != nil {
 				return nil, err
 			}
@@ -87,6 +147,18 @@ func (e *Repository) Get() ([]Element, error) {
 	return lastElements, nil
 }
 
+func retrieveShadowRoots(hosts []Element) ([]Client, error) {
+	shadowRoots := []Client{}
+	for _, host := range hosts {
+		shadowRoot, err := host.GetShadowRoot()
+		if err != nil {
+			return nil, err
+		}
+		shadowRoots = append(shadowRoots, shadowRoot)
+	}
+	return shadowRoots, nil
+}
+
 func retrieveElements(client Client, selector target.Selector) ([]Element, error) {
 	if selector.Single {
 		elements, err := client.GetElements(selector.API())
@@ -95,7 +167,7 @@ func retrieveElements(client Client, selector target.Selector) ([]Element, error
 		}
 
 		if len(elements) == 0 {
-			return nil, errors.New("element not found")
+			return nil, ErrNotFound
 		} else if len(elements) > 1 {
 			return nil, errors.New("ambiguous find")
 		}
@@ -103,17 +175,25 @@ func retrieveElements(client Client, selector target.Selector) ([]Element, error
 		return []Element{Element(elements[0])}, nil
 	}
 
-	if selector.Indexed && selector.Index > 0 {
+	if selector.Indexed && selector.Index != 0 {
 		elements, err := client.GetElements(selector.API())
 		if err != nil {
 			return nil, err
 		}
 
-		if selector.Index >= len(elements) {
+		index := selector.Index
+		if index < 0 {
+			index += len(elements)
+			if index < 0 {
+				return nil, fmt.Errorf("index %d of %d elements: element index out of range", selector.Index, len(elements))
+			}
+		}
+
+		if index >= len(elements) {
 			return nil, errors.New("element index out of range")
 		}
 
-		return []Element{Element(elements[selector.Index])}, nil
+		return []Element{Element(elements[index])}, nil
 	}
 
 	if selector.Indexed && selector.Index == 0 {
@@ -25,7 +25,7 @@ var _ = Describe("ElementRepository", func() {
 
 	Describe("#GetAtLeastOne", func() {
 		BeforeEach(func() {
-			repository.Selectors = target.Selectors{target.Selector{}}
+			repository.Selectors = target.Selectors{target.Selector{Type: target.CSS, Value: "#selector"}}
 		})
 
 		Context("when the client fails to retrieve any elements", func() {
@@ -57,7 +57,7 @@ var _ = Describe("ElementRepository", func() {
 
 	Describe("#GetExactlyOne", func() {
 		BeforeEach(func() {
-			repository.Selectors = target.Selectors{target.Selector{}}
+			repository.Selectors = target.Selectors{target.Selector{Type: target.CSS, Value: "#selector"}}
 		})
 
 		Context("when the client retrieves zero elements", func() {
@@ -187,6 +187,63 @@ var _ = Describe("ElementRepository", func() {
 			})
 		})
 
+		Context("when a selector has an empty value", func() {
+			It("should return an error without retrieving any elements", func() {
+				repository.Selectors = target.Selectors{parentSelector, target.Selector{Type: target.XPath, Value: " "}}
+				_, err := repository.Get()
+				Expect(err).To(MatchError("invalid selector: empty XPath value"))
+				Expect(client.GetElementsCall.Selector).To(Equal(api.Selector{}))
+			})
+		})
+
+		Context("when the selection passes through a shadow root", func() {
+			BeforeEach(func() {
+				client.GetElementsCall.ReturnElements = []*api.Element{firstParent}
+				firstParentBus.SendCall.Result = `{"shadow-6066-11e4-a52e-4f735466cecf": "some shadow root"}`
+				repository.Selectors = target.Selectors{parentSelector, target.Selector{Type: target.ShadowRoot}, childSelector}
+			})
+
+			It("should retrieve the child elements from the shadow root of each parent", func() {
+				_, err := repository.Get()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(firstParentBus.SendCall.Method).To(Equal("POST"))
+				Expect(firstParentBus.SendCall.Endpoint).To(Equal("shadow/some shadow root/elements"))
+				Expect(firstParentBus.SendCall.BodyJSON).To(MatchJSON(childSelectorJSON))
+			})
+
+			Context("when a parent shadow root cannot be retrieved", func() {
+				It("should return an error", func() {
+					firstParentBus.SendCall.Err = errors.New("some error")
+					_, err := repository.Get()
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the selection starts with a shadow root", func() {
+			It("should return an error", func() {
+				repository.Selectors = target.Selectors{target.Selector{Type: target.ShadowRoot}, childSelector}
+				_, err := repository.Get()
+				Expect(err).To(MatchError("shadow root selection requires a host element"))
+			})
+		})
+
+		Context("when the selection starts with a script result", func() {
+			It("should return an error", func() {
+				repository.Selectors = target.Selectors{target.Selector{Type: target.Script, Single: true}, childSelector}
+				_, err := repository.Get()
+				Expect(err).To(MatchError("elements returned by a script cannot be selected again"))
+			})
+		})
+
+		Context("when the selection ends with a shadow root", func() {
+			It("should return an error", func() {
+				repository.Selectors = target.Selectors{parentSelector, target.Selector{Type: target.ShadowRoot}}
+				_, err := repository.Get()
+				Expect(err).To(MatchError("shadow root selection must be followed by a selector"))
+			})
+		})
+
 		Context("when a single-element-only parent selection refers to multiple parents", func() {
 			It("should return an error", func() {
 				parentSelector.Single = true
@@ -236,6 +293,25 @@ var _ = Describe("ElementRepository", func() {
 			})
 		})
 
+		Context("when a negative index is used", func() {
+			It("should retrieve the element counting from the end", func() {
+				parentSelector.Index = -1
+				parentSelector.Indexed = true
+				repository.Selectors = target.Selectors{parentSelector}
+				Expect(repository.Get()).To(Equal([]Element{Element(secondParent)}))
+			})
+
+			Context("when the negative index is out of range", func() {
+				It("should return an error", func() {
+					parentSelector.Index = -3
+					parentSelector.Indexed = true
+					repository.Selectors = target.Selectors{parentSelector}
+					_, err := repository.Get()
+					Expect(err).To(MatchError("index -3 of 2 elements: element index out of range"))
+				})
+			})
+		})
+
 		Context("when child selection indices are out of range", func() {
 			It("should return an error", func() {
 				parentSelector.Index = 1
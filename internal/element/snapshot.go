@@ -0,0 +1,19 @@
+package element
+
+// A Snapshot provides a fixed list of previously retrieved elements in place
+// of a Repository, so that reading them does not query the WebDriver again.
+type Snapshot struct {
+	Elements []Element
+}
+
+func (s *Snapshot) Get() ([]Element, error) {
+	return s.Elements, nil
+}
+
+func (s *Snapshot) GetAtLeastOne() ([]Element, error) {
+	return atLeastOne(s.Elements)
+}
+
+func (s *Snapshot) GetExactlyOne() (Element, error) {
+	return exactlyOne(s.Elements)
+}
@@ -0,0 +1,56 @@
+package element_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/agouti/api"
+	. "github.com/sclevine/agouti/internal/element"
+)
+
+var _ = Describe("Snapshot", func() {
+	var (
+		firstElement  *api.Element
+		secondElement *api.Element
+	)
+
+	BeforeEach(func() {
+		firstElement = &api.Element{ID: "first"}
+		secondElement = &api.Element{ID: "second"}
+	})
+
+	Describe("#Get", func() {
+		It("should return the snapshot elements", func() {
+			snapshot := &Snapshot{[]Element{firstElement, secondElement}}
+			Expect(snapshot.Get()).To(Equal([]Element{firstElement, secondElement}))
+		})
+	})
+
+	Describe("#GetAtLeastOne", func() {
+		It("should return the snapshot elements", func() {
+			snapshot := &Snapshot{[]Element{firstElement}}
+			Expect(snapshot.GetAtLeastOne()).To(Equal([]Element{firstElement}))
+		})
+
+		Context("when the snapshot is empty", func() {
+			It("should return an error", func() {
+				_, err := (&Snapshot{}).GetAtLeastOne()
+				Expect(err).To(MatchError("no elements found"))
+			})
+		})
+	})
+
+	Describe("#GetExactlyOne", func() {
+		It("should return the only snapshot element", func() {
+			snapshot := &Snapshot{[]Element{firstElement}}
+			Expect(snapshot.GetExactlyOne()).To(Equal(firstElement))
+		})
+
+		Context("when the snapshot contains multiple elements", func() {
+			It("should return an error", func() {
+				snapshot := &Snapshot{[]Element{firstElement, secondElement}}
+				_, err := snapshot.GetExactlyOne()
+				Expect(err).To(MatchError("method does not support multiple elements (2)"))
+			})
+		})
+	})
+})
@@ -182,6 +182,23 @@ func testSelection(browserName string, newPage pageFunc) {
 				Expect(selection.All("option").At(1)).To(BeSelected())
 			})
 
+			By("selecting an option by index within option groups", func() {
+				selection := page.Find("#some_grouped_select")
+				Expect(selection.SelectByIndex(2)).To(Succeed())
+				Expect(selection.All("option").At(2)).To(BeSelected())
+				Expect(selection.SelectByIndex(3)).To(MatchError(HaveSuffix("index out of range (3 options)")))
+			})
+
+			By("reading the selected options within option groups", func() {
+				Expect(page.Find("#some_grouped_select").SelectedOptions()).To(Equal([]string{"fifth option", "seventh option"}))
+			})
+
+			By("deselecting all options within option groups", func() {
+				selection := page.Find("#some_grouped_select")
+				Expect(selection.DeselectAll()).To(Succeed())
+				Expect(selection.SelectedOptions()).To(BeEmpty())
+			})
+
 			By("submitting a form", func() {
 				Expect(page.Find("#some_form").Submit()).To(Succeed())
 				Eventually(func() bool { return submitted }).Should(BeTrue())
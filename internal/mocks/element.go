@@ -87,6 +87,37 @@ type Element struct {
 		ReturnY int
 		Err     error
 	}
+
+	GetSizeCall struct {
+		ReturnWidth  int
+		ReturnHeight int
+		Err          error
+	}
+
+	GetComputedLabelCall struct {
+		ReturnLabel string
+		Err         error
+	}
+
+	GetRectCall struct {
+		Called       bool
+		ReturnX      int
+		ReturnY      int
+		ReturnWidth  int
+		ReturnHeight int
+		Err          error
+	}
+
+	GetShadowRootCall struct {
+		ReturnShadowRoot *api.ShadowRoot
+		Err              error
+	}
+
+	GetPropertyCall struct {
+		Property    string
+		ReturnValue string
+		Err         error
+	}
 }
 
 func (e *Element) GetElement(selector api.Selector) (*api.Element, error) {
@@ -161,3 +192,25 @@ func (e *Element) IsEqualTo(other *api.Element) (bool, error) {
 func (e *Element) GetLocation() (x, y int, err error) {
 	return e.GetLocationCall.ReturnX, e.GetLocationCall.ReturnY, e.GetLocationCall.Err
 }
+
+func (e *Element) GetSize() (width, height int, err error) {
+	return e.GetSizeCall.ReturnWidth, e.GetSizeCall.ReturnHeight, e.GetSizeCall.Err
+}
+
+func (e *Element) GetComputedLabel() (string, error) {
+	return e.GetComputedLabelCall.ReturnLabel, e.GetComputedLabelCall.Err
+}
+
+func (e *Element) GetRect() (x, y, width, height int, err error) {
+	e.GetRectCall.Called = true
+	return e.GetRectCall.ReturnX, e.GetRectCall.ReturnY, e.GetRectCall.ReturnWidth, e.GetRectCall.ReturnHeight, e.GetRectCall.Err
+}
+
+func (e *Element) GetShadowRoot() (*api.ShadowRoot, error) {
+	return e.GetShadowRootCall.ReturnShadowRoot, e.GetShadowRootCall.Err
+}
+
+func (e *Element) GetProperty(property string) (string, error) {
+	e.GetPropertyCall.Property = property
+	return e.GetPropertyCall.ReturnValue, e.GetPropertyCall.Err
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"encoding/json"
+	"io"
 
 	"github.com/sclevine/agouti/api"
 )
@@ -258,6 +259,109 @@ type Session struct {
 		Called bool
 		Err    error
 	}
+
+	FullscreenCall struct {
+		Called bool
+		Err    error
+	}
+
+	PrintPDFCall struct {
+		Options   api.PrintOptions
+		ReturnPDF []byte
+		Err       error
+	}
+
+	CapabilitiesCall struct {
+		ReturnCapabilities map[string]interface{}
+	}
+
+	ProtocolCall struct {
+		ReturnProtocol string
+	}
+
+	KeysCall struct {
+		Text string
+		Err  error
+	}
+
+	SetNetworkConditionsCall struct {
+		Offline            bool
+		Latency            int
+		DownloadThroughput int
+		UploadThroughput   int
+		Err                error
+	}
+
+	ExecuteElementsCall struct {
+		Body           string
+		Arguments      []interface{}
+		ReturnElements []*api.Element
+		Err            error
+	}
+
+	SetTraceLoggerCall struct {
+		Logger io.Writer
+	}
+
+	PointerDoubleClickCall struct {
+		Element *api.Element
+		Err     error
+	}
+
+	PointerClickCall struct {
+		Element *api.Element
+		Button  api.Button
+		Err     error
+	}
+
+	SetTimeoutsCall struct {
+		Timeouts api.Timeouts
+		Err      error
+	}
+
+	EnableMetricsCall struct {
+		Called bool
+	}
+
+	MetricsCall struct {
+		ReturnMetrics map[string]api.EndpointMetric
+	}
+
+	ExecuteCDPCall struct {
+		Command string
+		Params  map[string]interface{}
+		Result  string
+		Err     error
+	}
+
+	GetWindowRectCall struct {
+		ReturnX      int
+		ReturnY      int
+		ReturnWidth  int
+		ReturnHeight int
+		Err          error
+	}
+
+	SetWindowRectCall struct {
+		X      int
+		Y      int
+		Width  int
+		Height int
+		Err    error
+	}
+
+	SetPermissionCall struct {
+		Name  string
+		State string
+		Err   error
+	}
+
+	ExecuteNestedElementsCall struct {
+		Body         string
+		Arguments    []interface{}
+		ReturnResult interface{}
+		Err          error
+	}
 }
 
 func (s *Session) Delete() error {
@@ -498,3 +602,99 @@ func (s *Session) SetScriptTimeout(timeout int) error {
 	s.SetScriptTimeoutCall.Called = true
 	return s.SetScriptTimeoutCall.Err
 }
+
+func (s *Session) Fullscreen() error {
+	s.FullscreenCall.Called = true
+	return s.FullscreenCall.Err
+}
+
+func (s *Session) PrintPDF(options api.PrintOptions) ([]byte, error) {
+	s.PrintPDFCall.Options = options
+	return s.PrintPDFCall.ReturnPDF, s.PrintPDFCall.Err
+}
+
+func (s *Session) Capabilities() map[string]interface{} {
+	return s.CapabilitiesCall.ReturnCapabilities
+}
+
+func (s *Session) Protocol() string {
+	return s.ProtocolCall.ReturnProtocol
+}
+
+func (s *Session) Keys(text string) error {
+	s.KeysCall.Text = text
+	return s.KeysCall.Err
+}
+
+func (s *Session) SetNetworkConditions(offline bool, latency, downloadThroughput, uploadThroughput int) error {
+	s.SetNetworkConditionsCall.Offline = offline
+	s.SetNetworkConditionsCall.Latency = latency
+	s.SetNetworkConditionsCall.DownloadThroughput = downloadThroughput
+	s.SetNetworkConditionsCall.UploadThroughput = uploadThroughput
+	return s.SetNetworkConditionsCall.Err
+}
+
+func (s *Session) ExecuteElements(body string, arguments []interface{}) ([]*api.Element, error) {
+	s.ExecuteElementsCall.Body = body
+	s.ExecuteElementsCall.Arguments = arguments
+	return s.ExecuteElementsCall.ReturnElements, s.ExecuteElementsCall.Err
+}
+
+func (s *Session) SetTraceLogger(logger io.Writer) {
+	s.SetTraceLoggerCall.Logger = logger
+}
+
+func (s *Session) PointerDoubleClick(element *api.Element) error {
+	s.PointerDoubleClickCall.Element = element
+	return s.PointerDoubleClickCall.Err
+}
+
+func (s *Session) PointerClick(element *api.Element, button api.Button) error {
+	s.PointerClickCall.Element = element
+	s.PointerClickCall.Button = button
+	return s.PointerClickCall.Err
+}
+
+func (s *Session) SetTimeouts(timeouts api.Timeouts) error {
+	s.SetTimeoutsCall.Timeouts = timeouts
+	return s.SetTimeoutsCall.Err
+}
+
+func (s *Session) EnableMetrics() {
+	s.EnableMetricsCall.Called = true
+}
+
+func (s *Session) Metrics() map[string]api.EndpointMetric {
+	return s.MetricsCall.ReturnMetrics
+}
+
+func (s *Session) ExecuteCDP(command string, params map[string]interface{}, result interface{}) error {
+	s.ExecuteCDPCall.Command = command
+	s.ExecuteCDPCall.Params = params
+	if result != nil {
+		json.Unmarshal([]byte(s.ExecuteCDPCall.Result), result)
+	}
+	return s.ExecuteCDPCall.Err
+}
+
+func (s *Session) GetWindowRect() (x, y, width, height int, err error) {
+	return s.GetWindowRectCall.ReturnX, s.GetWindowRectCall.ReturnY, s.GetWindowRectCall.ReturnWidth, s.GetWindowRectCall.ReturnHeight, s.GetWindowRectCall.Err
+}
+
+func (s *Session) SetWindowRect(x, y, width, height int) error {
+	s.SetWindowRectCall.X, s.SetWindowRectCall.Y = x, y
+	s.SetWindowRectCall.Width, s.SetWindowRectCall.Height = width, height
+	return s.SetWindowRectCall.Err
+}
+
+func (s *Session) SetPermission(name, state string) error {
+	s.SetPermissionCall.Name = name
+	s.SetPermissionCall.State = state
+	return s.SetPermissionCall.Err
+}
+
+func (s *Session) ExecuteNestedElements(body string, arguments []interface{}) (interface{}, error) {
+	s.ExecuteNestedElementsCall.Body = body
+	s.ExecuteNestedElementsCall.Arguments = arguments
+	return s.ExecuteNestedElementsCall.ReturnResult, s.ExecuteNestedElementsCall.Err
+}
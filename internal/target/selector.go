@@ -2,6 +2,8 @@ package target
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/sclevine/agouti/api"
 )
@@ -20,21 +22,72 @@ const (
 	IOSAut     Type = "iOS UIAut.: %s"
 	Class      Type = "Class: %s"
 	ID         Type = "ID: %s"
+	ShadowRoot Type = "Shadow Root%s"
+	Script     Type = "Script Result%s"
+	TestID     Type = "TestID: %s"
 
 	labelXPath  = `//input[@id=(//label[normalize-space()="%s"]/@for)] | //label[normalize-space()="%[1]s"]/input`
 	buttonXPath = `//input[@type="submit" or @type="button"][normalize-space(@value)="%s"] | //button[normalize-space()="%[1]s"]`
 )
 
+// defaultTestIDAttribute is the attribute matched by TestID selectors when
+// no other attribute is set.
+const defaultTestIDAttribute = "data-testid"
+
+var (
+	testIDAttribute      = defaultTestIDAttribute
+	testIDAttributeMutex sync.RWMutex
+)
+
+// SetTestIDAttribute sets the attribute matched by TestID selectors that are
+// appended afterwards. Existing selectors keep the attribute they store.
+func SetTestIDAttribute(attribute string) {
+	testIDAttributeMutex.Lock()
+	defer testIDAttributeMutex.Unlock()
+	testIDAttribute = attribute
+}
+
+func currentTestIDAttribute() string {
+	testIDAttributeMutex.RLock()
+	defer testIDAttributeMutex.RUnlock()
+	return testIDAttribute
+}
+
+func testIDCSS(attribute, id string) string {
+	if attribute == "" {
+		attribute = defaultTestIDAttribute
+	}
+	return fmt.Sprintf(`[%s=%s]`, attribute, CSSString(id))
+}
+
+// cssStringEscaper escapes the characters that cannot appear unescaped in a
+// double-quoted CSS string. Newlines are written as hexadecimal escapes.
+var cssStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\a `)
+
+// CSSString returns the provided text as a double-quoted CSS string.
+func CSSString(text string) string {
+	return `"` + cssStringEscaper.Replace(text) + `"`
+}
+
 func (t Type) format(value string) string {
 	return fmt.Sprintf(string(t), value)
 }
 
+// Name returns the name of the selector type, ex. "CSS" or "XPath".
+func (t Type) Name() string {
+	return strings.SplitN(string(t), ":", 2)[0]
+}
+
 type Selector struct {
 	Type    Type
 	Value   string
 	Index   int
 	Indexed bool
 	Single  bool
+
+	// Attribute is the attribute matched by a TestID selector. It is set
+	// when the selector is appended, so later changes do not affect it.
+	Attribute string
 }
 
 func (s Selector) String() string {
@@ -49,13 +102,24 @@ func (s Selector) String() string {
 	return s.Type.format(s.Value) + suffix
 }
 
+// Validate returns an error if the selector cannot be used to find elements.
+func (s Selector) Validate() error {
+	if s.Type == ShadowRoot || s.Type == Script {
+		return nil
+	}
+	if strings.TrimSpace(s.Value) == "" {
+		return fmt.Errorf("invalid selector: empty %s value", s.Type.Name())
+	}
+	return nil
+}
+
 func (s Selector) API() api.Selector {
 	return api.Selector{Using: s.apiType(), Value: s.value()}
 }
 
 func (s Selector) apiType() string {
 	switch s.Type {
-	case CSS:
+	case CSS, TestID:
 		return "css selector"
 	case Class:
 		return "class name"
@@ -81,6 +145,8 @@ func (s Selector) value() string {
 		return fmt.Sprintf(labelXPath, s.Value)
 	case Button:
 		return fmt.Sprintf(buttonXPath, s.Value)
+	case TestID:
+		return testIDCSS(s.Attribute, s.Value)
 	}
 	return s.Value
 }
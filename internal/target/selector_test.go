@@ -22,10 +22,32 @@ var _ = Describe("Selector", func() {
 			Expect(Selector{Type: Label, Value: "value"}.String()).To(Equal(`Label: "value"`))
 			Expect(Selector{Type: Button, Value: "value"}.String()).To(Equal(`Button: "value"`))
 			Expect(Selector{Type: Name, Value: "value"}.String()).To(Equal(`Name: "value"`))
+			Expect(Selector{Type: ShadowRoot}.String()).To(Equal("Shadow Root"))
+			Expect(Selector{Type: TestID, Value: "value"}.String()).To(Equal("TestID: value"))
+			Expect(Selector{Type: Script, Indexed: true, Index: 1}.String()).To(Equal("Script Result [1]"))
 
 		})
 	})
 
+	Describe("#Validate", func() {
+		It("should successfully validate a selector with a value", func() {
+			Expect(Selector{Type: CSS, Value: "value"}.Validate()).To(Succeed())
+		})
+
+		It("should return an error naming the selector type when the value is empty", func() {
+			Expect(Selector{Type: CSS, Value: ""}.Validate()).To(MatchError("invalid selector: empty CSS value"))
+			Expect(Selector{Type: Link, Value: "  "}.Validate()).To(MatchError("invalid selector: empty Link value"))
+		})
+
+		It("should successfully validate a shadow root selector without a value", func() {
+			Expect(Selector{Type: ShadowRoot}.Validate()).To(Succeed())
+		})
+
+		It("should successfully validate a script result selector without a value", func() {
+			Expect(Selector{Type: Script, Single: true}.Validate()).To(Succeed())
+		})
+	})
+
 	Describe("#API", func() {
 		It("should return an API-consumable version of the Selector", func() {
 			Expect(Selector{Type: CSS, Value: "value"}.API()).To(Equal(api.Selector{Using: "css selector", Value: "value"}))
@@ -34,6 +56,15 @@ var _ = Describe("Selector", func() {
 			Expect(Selector{Type: Label, Value: "value"}.API()).To(Equal(api.Selector{Using: "xpath", Value: `//input[@id=(//label[normalize-space()="value"]/@for)] | //label[normalize-space()="value"]/input`}))
 			Expect(Selector{Type: Button, Value: "value"}.API()).To(Equal(api.Selector{Using: "xpath", Value: `//input[@type="submit" or @type="button"][normalize-space(@value)="value"] | //button[normalize-space()="value"]`}))
 			Expect(Selector{Type: Name, Value: "value"}.API()).To(Equal(api.Selector{Using: "name", Value: "value"}))
+			Expect(Selector{Type: TestID, Value: "value"}.API()).To(Equal(api.Selector{Using: "css selector", Value: `[data-testid="value"]`}))
+		})
+
+		It("should match the attribute stored in a TestID selector", func() {
+			Expect(Selector{Type: TestID, Value: "value", Attribute: "data-cy"}.API()).To(Equal(api.Selector{Using: "css selector", Value: `[data-cy="value"]`}))
+		})
+
+		It("should escape quotes and backslashes in a TestID selector", func() {
+			Expect(Selector{Type: TestID, Value: `say "hi" \ bye`}.API()).To(Equal(api.Selector{Using: "css selector", Value: `[data-testid="say \"hi\" \\ bye"]`}))
 		})
 	})
 })
@@ -14,9 +14,17 @@ func (s Selectors) canMergeType(selectorType Type) bool {
 }
 
 func (s Selectors) Append(selectorType Type, value string) Selectors {
-	selector := Selector{Type: selectorType, Value: value}
+	var attribute string
+	if selectorType == TestID {
+		attribute = currentTestIDAttribute()
+		if s.canMergeType(CSS) && strings.TrimSpace(value) != "" {
+			return s.Append(CSS, testIDCSS(attribute, value))
+		}
+	}
+
+	selector := Selector{Type: selectorType, Value: value, Attribute: attribute}
 
-	if s.canMergeType(selectorType) {
+	if s.canMergeType(selectorType) && strings.TrimSpace(value) != "" {
 		lastIndex := len(s) - 1
 		selector.Value = s[lastIndex].Value + " " + selector.Value
 		return s[:lastIndex].append(selector)
@@ -49,6 +57,12 @@ func (s Selectors) At(index int) Selectors {
 	return s[:lastIndex].append(selector)
 }
 
+// ShadowRoot returns selectors that refer to the shadow roots of the
+// elements matched by s.
+func (s Selectors) ShadowRoot() Selectors {
+	return s.append(Selector{Type: ShadowRoot})
+}
+
 func (s Selectors) String() string {
 	var tags []string
 
@@ -59,6 +73,8 @@ func (s Selectors) String() string {
 	return strings.Join(tags, " | ")
 }
 
+// append always returns a new slice so that selectors derived from a shared
+// parent never write into the parent's backing array or into each other.
 func (s Selectors) append(selector Selector) Selectors {
 	selectorsCopy := append(Selectors(nil), s...)
 	return append(selectorsCopy, selector)
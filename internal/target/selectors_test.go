@@ -29,6 +29,12 @@ var _ = Describe("Selectors", func() {
 				})
 			})
 
+			Context("when the new selector is empty", func() {
+				It("should append a new selector so that it can be reported as invalid", func() {
+					Expect(selectors.Append(CSS, "#selector").Append(CSS, "").String()).To(Equal("CSS: #selector | CSS: "))
+				})
+			})
+
 			Context("when there are no selectors", func() {
 				It("should append a new selector", func() {
 					Expect(selectors.Append(CSS, "#selector").String()).To(Equal("CSS: #selector"))
@@ -56,6 +62,41 @@ var _ = Describe("Selectors", func() {
 		})
 	})
 
+	Describe("#Append with a TestID selector", func() {
+		It("should append a new TestID selector when there are no selectors", func() {
+			Expect(selectors.Append(TestID, "submit").String()).To(Equal("TestID: submit"))
+		})
+
+		It("should merge into a preceding unindexed CSS selector", func() {
+			Expect(selectors.Append(CSS, "#form").Append(TestID, "submit").String()).To(Equal(`CSS: #form [data-testid="submit"]`))
+		})
+
+		It("should append a new TestID selector after a non-CSS or indexed selector", func() {
+			Expect(selectors.Append(XPath, "//form").Append(TestID, "submit").String()).To(Equal("XPath: //form | TestID: submit"))
+			Expect(selectors.Append(CSS, "#form").Single().Append(TestID, "submit").String()).To(Equal("CSS: #form [single] | TestID: submit"))
+		})
+
+		Context("when the test ID attribute is changed", func() {
+			AfterEach(func() {
+				SetTestIDAttribute("data-testid")
+			})
+
+			It("should store the attribute that is set when the selector is appended", func() {
+				SetTestIDAttribute("data-cy")
+				Expect(selectors.Append(TestID, "submit")[0].Attribute).To(Equal("data-cy"))
+				Expect(selectors.Append(CSS, "#form").Append(TestID, "submit").String()).To(Equal(`CSS: #form [data-cy="submit"]`))
+			})
+
+			It("should not change selectors that were already appended", func() {
+				unmerged := selectors.Append(TestID, "submit")
+				merged := selectors.Append(CSS, "#form").Append(TestID, "submit")
+				SetTestIDAttribute("data-cy")
+				Expect(unmerged[0].API().Value).To(Equal(`[data-testid="submit"]`))
+				Expect(merged.String()).To(Equal(`CSS: #form [data-testid="submit"]`))
+			})
+		})
+	})
+
 	Describe("#At", func() {
 		Context("when called on a selection with no selectors", func() {
 			It("should return an empty selection", func() {
@@ -84,6 +125,16 @@ var _ = Describe("Selectors", func() {
 		})
 	})
 
+	Describe("#ShadowRoot", func() {
+		It("should append a shadow root selector", func() {
+			Expect(selectors.Append(CSS, "my-widget").ShadowRoot().String()).To(Equal("CSS: my-widget | Shadow Root"))
+		})
+
+		It("should not merge subsequent CSS selectors across the shadow root", func() {
+			Expect(selectors.Append(CSS, "my-widget").ShadowRoot().Append(CSS, "button").String()).To(Equal("CSS: my-widget | Shadow Root | CSS: button"))
+		})
+	})
+
 	Describe("selectors are always copied", func() {
 		Context("when two CSS selections are created from the same XPath parent", func() {
 			It("should not overwrite the first created child", func() {
@@ -93,5 +144,27 @@ var _ = Describe("Selectors", func() {
 				Expect(firstChild.String()).To(Equal("XPath: //one | XPath: //two | XPath: //parent | CSS: #firstChild"))
 			})
 		})
+
+		Context("when two CSS selections are created from the same CSS parent", func() {
+			It("should merge each child into an independent copy of the parent", func() {
+				parent := selectors.Append(XPath, "//one").Append(CSS, "#parent")
+				firstChild := parent.Append(CSS, "#firstChild")
+				secondChild := parent.Append(CSS, "#secondChild")
+				Expect(firstChild.String()).To(Equal("XPath: //one | CSS: #parent #firstChild"))
+				Expect(secondChild.String()).To(Equal("XPath: //one | CSS: #parent #secondChild"))
+				Expect(parent.String()).To(Equal("XPath: //one | CSS: #parent"))
+			})
+		})
+
+		Context("when indexed and single-element-only selections are created from the same parent", func() {
+			It("should not modify the parent or each other", func() {
+				parent := selectors.Append(CSS, "#parent")
+				indexed := parent.At(1)
+				single := parent.Single()
+				Expect(indexed.String()).To(Equal("CSS: #parent [1]"))
+				Expect(single.String()).To(Equal("CSS: #parent [single]"))
+				Expect(parent.String()).To(Equal("CSS: #parent"))
+			})
+		})
 	})
 })
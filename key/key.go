@@ -0,0 +1,50 @@
+// Package key provides the special keys that may be sent to a WebDriver
+// using Page.SendKeys or Selection.Fill.
+//
+// For example, to press Enter:
+//    page.SendKeys(key.Enter)
+// See: https://www.w3.org/TR/webdriver/#keyboard-actions
+package key
+
+const (
+	Null       = "\uE000"
+	Cancel     = "\uE001"
+	Help       = "\uE002"
+	Backspace  = "\uE003"
+	Tab        = "\uE004"
+	Clear      = "\uE005"
+	Return     = "\uE006"
+	Enter      = "\uE007"
+	Shift      = "\uE008"
+	Control    = "\uE009"
+	Alt        = "\uE00A"
+	Pause      = "\uE00B"
+	Escape     = "\uE00C"
+	Space      = "\uE00D"
+	PageUp     = "\uE00E"
+	PageDown   = "\uE00F"
+	End        = "\uE010"
+	Home       = "\uE011"
+	ArrowLeft  = "\uE012"
+	ArrowUp    = "\uE013"
+	ArrowRight = "\uE014"
+	ArrowDown  = "\uE015"
+	Insert     = "\uE016"
+	Delete     = "\uE017"
+	Semicolon  = "\uE018"
+	Equals     = "\uE019"
+	F1         = "\uE031"
+	F2         = "\uE032"
+	F3         = "\uE033"
+	F4         = "\uE034"
+	F5         = "\uE035"
+	F6         = "\uE036"
+	F7         = "\uE037"
+	F8         = "\uE038"
+	F9         = "\uE039"
+	F10        = "\uE03A"
+	F11        = "\uE03B"
+	F12        = "\uE03C"
+	Meta       = "\uE03D"
+	Command    = Meta
+)
@@ -0,0 +1,29 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BePresentMatcher struct{}
+
+func (m *BePresentMatcher) Match(actual interface{}) (success bool, err error) {
+	actualSelection, ok := actual.(interface {
+		IsPresent() (bool, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("BePresent matcher requires a *Selection.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	return actualSelection.IsPresent()
+}
+
+func (m *BePresentMatcher) FailureMessage(actual interface{}) (message string) {
+	return booleanMessage(actual, "to be present")
+}
+
+func (m *BePresentMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return booleanMessage(actual, "not to be present")
+}
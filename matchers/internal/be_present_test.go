@@ -0,0 +1,70 @@
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers/internal"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("BePresentMatcher", func() {
+	var (
+		matcher   *BePresentMatcher
+		selection *mocks.Selection
+	)
+
+	BeforeEach(func() {
+		selection = &mocks.Selection{}
+		selection.StringCall.ReturnString = "selection 'CSS: #selector'"
+		matcher = &BePresentMatcher{}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a selection", func() {
+			Context("when the element is present", func() {
+				It("should successfully return true", func() {
+					selection.IsPresentCall.ReturnPresent = true
+					Expect(matcher.Match(selection)).To(BeTrue())
+				})
+			})
+
+			Context("when the element is not present", func() {
+				It("should successfully return false", func() {
+					selection.IsPresentCall.ReturnPresent = false
+					Expect(matcher.Match(selection)).To(BeFalse())
+				})
+			})
+
+			Context("when determining whether the element is present fails", func() {
+				It("should return an error", func() {
+					selection.IsPresentCall.Err = errors.New("some error")
+					_, err := matcher.Match(selection)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a selection", func() {
+			It("should return an error", func() {
+				_, err := matcher.Match("not a selection")
+				Expect(err).To(MatchError("BePresent matcher requires a *Selection.  Got:\n    <string>: not a selection"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("should return a failure message", func() {
+			message := matcher.FailureMessage(selection)
+			Expect(message).To(Equal("Expected selection 'CSS: #selector' to be present"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("should return a negated failure message", func() {
+			message := matcher.NegatedFailureMessage(selection)
+			Expect(message).To(Equal("Expected selection 'CSS: #selector' not to be present"))
+		})
+	})
+})
@@ -0,0 +1,31 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type HaveClassMatcher struct {
+	ExpectedClass string
+}
+
+func (m *HaveClassMatcher) Match(actual interface{}) (success bool, err error) {
+	actualSelection, ok := actual.(interface {
+		HasClass(class string) (bool, error)
+	})
+
+	if !ok {
+		return false, fmt.Errorf("HaveClass matcher requires a *Selection.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	return actualSelection.HasClass(m.ExpectedClass)
+}
+
+func (m *HaveClassMatcher) FailureMessage(actual interface{}) (message string) {
+	return equalityMessage(actual, "to have class", m.ExpectedClass)
+}
+
+func (m *HaveClassMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return equalityMessage(actual, "not to have class", m.ExpectedClass)
+}
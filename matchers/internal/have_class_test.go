@@ -0,0 +1,75 @@
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers/internal"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("HaveClassMatcher", func() {
+	var (
+		matcher   *HaveClassMatcher
+		selection *mocks.Selection
+	)
+
+	BeforeEach(func() {
+		selection = &mocks.Selection{}
+		selection.StringCall.ReturnString = "selection 'CSS: #selector'"
+		matcher = &HaveClassMatcher{ExpectedClass: "some-class"}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual object is a selection", func() {
+			It("should request the provided class", func() {
+				matcher.Match(selection)
+				Expect(selection.HasClassCall.Class).To(Equal("some-class"))
+			})
+
+			Context("when the element has the class", func() {
+				It("should successfully return true", func() {
+					selection.HasClassCall.ReturnHas = true
+					Expect(matcher.Match(selection)).To(BeTrue())
+				})
+			})
+
+			Context("when the element does not have the class", func() {
+				It("should successfully return false", func() {
+					selection.HasClassCall.ReturnHas = false
+					Expect(matcher.Match(selection)).To(BeFalse())
+				})
+			})
+
+			Context("when determining the classes fails", func() {
+				It("should return an error", func() {
+					selection.HasClassCall.Err = errors.New("some error")
+					_, err := matcher.Match(selection)
+					Expect(err).To(MatchError("some error"))
+				})
+			})
+		})
+
+		Context("when the actual object is not a selection", func() {
+			It("should return an error", func() {
+				_, err := matcher.Match("not a selection")
+				Expect(err).To(MatchError("HaveClass matcher requires a *Selection.  Got:\n    <string>: not a selection"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("should return a failure message", func() {
+			message := matcher.FailureMessage(selection)
+			Expect(message).To(Equal("Expected selection 'CSS: #selector' to have class\n    some-class"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("should return a negated failure message", func() {
+			message := matcher.NegatedFailureMessage(selection)
+			Expect(message).To(Equal("Expected selection 'CSS: #selector' not to have class\n    some-class"))
+		})
+	})
+})
@@ -30,9 +30,9 @@ func (m *MatchTextMatcher) Match(actual interface{}) (success bool, err error) {
 }
 
 func (m *MatchTextMatcher) FailureMessage(actual interface{}) (message string) {
-	return valueMessage(actual, "to have text matching", m.Regexp, m.actualText)
+	return truncatedTextMessage(actual, "to have text matching", m.Regexp, m.actualText)
 }
 
 func (m *MatchTextMatcher) NegatedFailureMessage(actual interface{}) (message string) {
-	return valueMessage(actual, "not to have text matching", m.Regexp, m.actualText)
+	return truncatedTextMessage(actual, "not to have text matching", m.Regexp, m.actualText)
 }
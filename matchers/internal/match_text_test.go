@@ -2,6 +2,7 @@ package internal_test
 
 import (
 	"errors"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -70,6 +71,27 @@ var _ = Describe("MatchTextMatcher", func() {
 			Expect(message).To(ContainSubstring("Expected selection 'CSS: #selector' to have text matching\n    s[^t]+text"))
 			Expect(message).To(ContainSubstring("but found\n    some other text"))
 		})
+
+		Context("when the actual text is long", func() {
+			It("should return a failure message with the middle of the text omitted", func() {
+				selection.TextCall.ReturnText = "start" + strings.Repeat("a", 100) + "end"
+				matcher.Match(selection)
+				message := matcher.FailureMessage(selection)
+				Expect(message).To(ContainSubstring("Expected selection 'CSS: #selector' to have text matching\n    s[^t]+text"))
+				Expect(message).To(ContainSubstring("but found\n    start" + strings.Repeat("a", 20) + "...(58 characters omitted)..." + strings.Repeat("a", 22) + "end"))
+			})
+
+			It("should count multi-byte characters as single characters", func() {
+				selection.TextCall.ReturnText = strings.Repeat("é", 50)
+				matcher.Match(selection)
+				Expect(matcher.FailureMessage(selection)).To(ContainSubstring("but found\n    " + strings.Repeat("é", 50)))
+
+				selection.TextCall.ReturnText = "ü" + strings.Repeat("é", 58) + "ö"
+				matcher.Match(selection)
+				message := matcher.FailureMessage(selection)
+				Expect(message).To(ContainSubstring("but found\n    ü" + strings.Repeat("é", 24) + "...(10 characters omitted)..." + strings.Repeat("é", 24) + "ö"))
+			})
+		})
 	})
 
 	Describe("#NegatedFailureMessage", func() {
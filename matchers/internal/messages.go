@@ -2,6 +2,8 @@ package internal
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/onsi/gomega/format"
 )
@@ -13,6 +15,36 @@ func valueMessage(actual interface{}, message string, expected, actualValue inte
 	return fmt.Sprintf(failureMessage, actual, message, tab, expected, tab, actualValue)
 }
 
+// textDiffMessage is like valueMessage, but when both the expected and actual
+// text are long, only the text surrounding their first difference is shown and
+// the difference is marked. Text length is measured in characters, not bytes.
+func textDiffMessage(actual interface{}, message, expected, actualText string) string {
+	threshold := int(format.TruncateThreshold)
+	if !format.TruncatedDiff || utf8.RuneCountInString(expected) < threshold || utf8.RuneCountInString(actualText) < threshold {
+		return valueMessage(actual, message, expected, actualText)
+	}
+
+	diff := format.MessageWithDiff(expected, "but found", actualText)
+	return fmt.Sprintf("Expected %s %s\n%s", actual, message, strings.TrimPrefix(diff, "Expected\n"))
+}
+
+// truncatedTextMessage is like valueMessage, but when the actual text is long,
+// only its beginning and end are shown. A regular expression does not have a
+// single point where it diverges from the text, so the text cannot be diffed.
+func truncatedTextMessage(actual interface{}, message, expected, actualText string) string {
+	threshold := int(format.TruncateThreshold)
+	if !format.TruncatedDiff || utf8.RuneCountInString(actualText) <= threshold {
+		return valueMessage(actual, message, expected, actualText)
+	}
+
+	characters := []rune(actualText)
+	context := threshold / 2
+	omitted := len(characters) - 2*context
+	truncatedText := fmt.Sprintf("%s...(%d characters omitted)...%s",
+		string(characters[:context]), omitted, string(characters[len(characters)-context:]))
+	return valueMessage(actual, message, expected, truncatedText)
+}
+
 func booleanMessage(actual interface{}, message string) string {
 	failureMessage := "Expected %s %s"
 	return fmt.Sprintf(failureMessage, actual, message)
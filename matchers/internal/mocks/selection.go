@@ -22,6 +22,12 @@ type Selection struct {
 		Err         error
 	}
 
+	HasClassCall struct {
+		Class     string
+		ReturnHas bool
+		Err       error
+	}
+
 	SelectedCall struct {
 		ReturnSelected bool
 		Err            error
@@ -47,11 +53,24 @@ type Selection struct {
 		Err         error
 	}
 
+	IsPresentCall struct {
+		ReturnPresent bool
+		Err           error
+	}
+
 	EqualsElementCall struct {
 		Selection    interface{}
 		ReturnEquals bool
 		Err          error
 	}
+
+	RectCall struct {
+		ReturnX      int
+		ReturnY      int
+		ReturnWidth  int
+		ReturnHeight int
+		Err          error
+	}
 }
 
 func (s *Selection) String() string {
@@ -72,6 +91,11 @@ func (s *Selection) CSS(property string) (string, error) {
 	return s.CSSCall.ReturnValue, s.CSSCall.Err
 }
 
+func (s *Selection) HasClass(class string) (bool, error) {
+	s.HasClassCall.Class = class
+	return s.HasClassCall.ReturnHas, s.HasClassCall.Err
+}
+
 func (s *Selection) Selected() (bool, error) {
 	return s.SelectedCall.ReturnSelected, s.SelectedCall.Err
 }
@@ -92,7 +116,15 @@ func (s *Selection) Count() (int, error) {
 	return s.CountCall.ReturnCount, s.CountCall.Err
 }
 
+func (s *Selection) IsPresent() (bool, error) {
+	return s.IsPresentCall.ReturnPresent, s.IsPresentCall.Err
+}
+
 func (s *Selection) EqualsElement(selection interface{}) (bool, error) {
 	s.EqualsElementCall.Selection = selection
 	return s.EqualsElementCall.ReturnEquals, s.EqualsElementCall.Err
 }
+
+func (s *Selection) Rect() (x, y, width, height int, err error) {
+	return s.RectCall.ReturnX, s.RectCall.ReturnY, s.RectCall.ReturnWidth, s.RectCall.ReturnHeight, s.RectCall.Err
+}
@@ -0,0 +1,81 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type rectSelection interface {
+	Rect() (x, y, width, height int, err error)
+}
+
+type rect struct {
+	x, y, width, height int
+}
+
+func (r rect) String() string {
+	return fmt.Sprintf("at (%d, %d) with size %dx%d", r.x, r.y, r.width, r.height)
+}
+
+type RelativePositionMatcher struct {
+	Method            string
+	Relation          string
+	ExpectedSelection interface{}
+	actualRect        rect
+	expectedRect      rect
+}
+
+func (m *RelativePositionMatcher) Match(actual interface{}) (success bool, err error) {
+	actualSelection, ok := actual.(rectSelection)
+	if !ok {
+		return false, fmt.Errorf("%s matcher requires a *Selection.  Got:\n%s", m.Method, format.Object(actual, 1))
+	}
+
+	expectedSelection, ok := m.ExpectedSelection.(rectSelection)
+	if !ok {
+		return false, fmt.Errorf("%s matcher requires a *Selection to compare to.  Got:\n%s", m.Method, format.Object(m.ExpectedSelection, 1))
+	}
+
+	if m.actualRect, err = selectionRect(actualSelection); err != nil {
+		return false, err
+	}
+	if m.expectedRect, err = selectionRect(expectedSelection); err != nil {
+		return false, err
+	}
+
+	actualRect, expectedRect := m.actualRect, m.expectedRect
+	switch m.Relation {
+	case "above":
+		return actualRect.y+actualRect.height <= expectedRect.y, nil
+	case "below":
+		return actualRect.y >= expectedRect.y+expectedRect.height, nil
+	case "left of":
+		return actualRect.x+actualRect.width <= expectedRect.x, nil
+	case "right of":
+		return actualRect.x >= expectedRect.x+expectedRect.width, nil
+	}
+	return false, fmt.Errorf("%s matcher has unknown relation '%s'", m.Method, m.Relation)
+}
+
+func selectionRect(selection rectSelection) (rect, error) {
+	x, y, width, height, err := selection.Rect()
+	if err != nil {
+		return rect{}, err
+	}
+	return rect{x, y, width, height}, nil
+}
+
+func (m *RelativePositionMatcher) FailureMessage(actual interface{}) (message string) {
+	return m.positionMessage(actual, "to be "+m.Relation)
+}
+
+func (m *RelativePositionMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return m.positionMessage(actual, "not to be "+m.Relation)
+}
+
+func (m *RelativePositionMatcher) positionMessage(actual interface{}, message string) string {
+	failureMessage := "Expected %s %s\n%s%s\nbut found\n%s%s %s\n%s%s %s"
+	return fmt.Sprintf(failureMessage, actual, message, tab, m.ExpectedSelection,
+		tab, actual, m.actualRect, tab, m.ExpectedSelection, m.expectedRect)
+}
@@ -0,0 +1,130 @@
+package internal_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti/matchers/internal"
+	"github.com/sclevine/agouti/matchers/internal/mocks"
+)
+
+var _ = Describe("RelativePositionMatcher", func() {
+	var (
+		matcher   *RelativePositionMatcher
+		selection *mocks.Selection
+		other     *mocks.Selection
+	)
+
+	BeforeEach(func() {
+		selection = &mocks.Selection{}
+		other = &mocks.Selection{}
+		selection.StringCall.ReturnString = "selection 'CSS: #header'"
+		other.StringCall.ReturnString = "selection 'CSS: #footer'"
+		selection.RectCall.ReturnX = 10
+		selection.RectCall.ReturnY = 20
+		selection.RectCall.ReturnWidth = 100
+		selection.RectCall.ReturnHeight = 50
+		other.RectCall.ReturnX = 10
+		other.RectCall.ReturnY = 70
+		other.RectCall.ReturnWidth = 100
+		other.RectCall.ReturnHeight = 30
+		matcher = &RelativePositionMatcher{Method: "BeAbove", Relation: "above", ExpectedSelection: other}
+	})
+
+	Describe("#Match", func() {
+		Context("when the actual element is above the expected element", func() {
+			It("should successfully return true", func() {
+				Expect(matcher.Match(selection)).To(BeTrue())
+			})
+		})
+
+		Context("when the actual element overlaps the expected element", func() {
+			It("should successfully return false", func() {
+				other.RectCall.ReturnY = 69
+				Expect(matcher.Match(selection)).To(BeFalse())
+			})
+		})
+
+		Context("when the elements have negative coordinates", func() {
+			It("should compare the edges of the elements", func() {
+				selection.RectCall.ReturnY = -60
+				other.RectCall.ReturnY = -10
+				Expect(matcher.Match(selection)).To(BeTrue())
+				other.RectCall.ReturnY = -11
+				Expect(matcher.Match(selection)).To(BeFalse())
+			})
+		})
+
+		Context("when the relation is below", func() {
+			It("should compare the top edge of the actual element to the bottom edge of the expected element", func() {
+				matcher.Relation = "below"
+				Expect(matcher.Match(other)).To(BeFalse())
+				matcher.ExpectedSelection = selection
+				Expect(matcher.Match(other)).To(BeTrue())
+			})
+		})
+
+		Context("when the relation is left of or right of", func() {
+			It("should compare the horizontal edges of the elements", func() {
+				other.RectCall.ReturnX = 110
+				matcher.Relation = "left of"
+				Expect(matcher.Match(selection)).To(BeTrue())
+				matcher.Relation = "right of"
+				Expect(matcher.Match(selection)).To(BeFalse())
+			})
+		})
+
+		Context("when the actual rect cannot be retrieved", func() {
+			It("should return an error", func() {
+				selection.RectCall.Err = errors.New("some error")
+				_, err := matcher.Match(selection)
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the expected rect cannot be retrieved", func() {
+			It("should return an error", func() {
+				other.RectCall.Err = errors.New("some error")
+				_, err := matcher.Match(selection)
+				Expect(err).To(MatchError("some error"))
+			})
+		})
+
+		Context("when the actual object is not a selection", func() {
+			It("should return an error", func() {
+				_, err := matcher.Match("not a selection")
+				Expect(err).To(MatchError("BeAbove matcher requires a *Selection.  Got:\n    <string>: not a selection"))
+			})
+		})
+
+		Context("when the expected object is not a selection", func() {
+			It("should return an error", func() {
+				matcher.ExpectedSelection = "not a selection"
+				_, err := matcher.Match(selection)
+				Expect(err).To(MatchError("BeAbove matcher requires a *Selection to compare to.  Got:\n    <string>: not a selection"))
+			})
+		})
+	})
+
+	Describe("#FailureMessage", func() {
+		It("should return a failure message including the coordinates of both elements", func() {
+			other.RectCall.ReturnY = 0
+			matcher.Match(selection)
+			message := matcher.FailureMessage(selection)
+			Expect(message).To(Equal("Expected selection 'CSS: #header' to be above\n" +
+				"    selection 'CSS: #footer'\n" +
+				"but found\n" +
+				"    selection 'CSS: #header' at (10, 20) with size 100x50\n" +
+				"    selection 'CSS: #footer' at (10, 0) with size 100x30"))
+		})
+	})
+
+	Describe("#NegatedFailureMessage", func() {
+		It("should return a negated failure message", func() {
+			matcher.Match(selection)
+			message := matcher.NegatedFailureMessage(selection)
+			Expect(message).To(HavePrefix("Expected selection 'CSS: #header' not to be above\n    selection 'CSS: #footer'\nbut found\n"))
+		})
+	})
+})
@@ -32,6 +32,11 @@ func (m *ValueMatcher) Match(actual interface{}) (success bool, err error) {
 }
 
 func (m *ValueMatcher) FailureMessage(actual interface{}) (message string) {
+	expectedText, expectedIsText := m.Expected.(string)
+	actualText, actualIsText := m.actualValue.(string)
+	if expectedIsText && actualIsText {
+		return textDiffMessage(actual, fmt.Sprintf("to have %s equaling", m.Property), expectedText, actualText)
+	}
 	return valueMessage(actual, fmt.Sprintf("to have %s equaling", m.Property), m.Expected, m.actualValue)
 }
 
@@ -2,6 +2,7 @@ package internal_test
 
 import (
 	"errors"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -64,6 +65,44 @@ var _ = Describe("ValueMatcher", func() {
 		})
 	})
 
+	Describe("#FailureMessage", func() {
+		Context("when the expected and actual text are long", func() {
+			It("should return a failure message marking where the text differs", func() {
+				matcher.Expected = strings.Repeat("a", 60) + "b" + strings.Repeat("a", 60)
+				selection.TextCall.ReturnText = strings.Repeat("a", 60) + "z" + strings.Repeat("a", 60)
+				matcher.Match(selection)
+				message := matcher.FailureMessage(selection)
+				Expect(message).To(HavePrefix("Expected selection 'CSS: #selector' to have text equaling\n"))
+				Expect(message).To(ContainSubstring(`<string>: "...aaaaabaaaaa..."`))
+				Expect(message).To(ContainSubstring(`<string>: "...aaaaazaaaaa..."`))
+				Expect(message).To(MatchRegexp(`but found +\|`))
+				Expect(message).NotTo(ContainSubstring(strings.Repeat("a", 60)))
+			})
+		})
+
+		Context("when the expected and actual text are short but contain multi-byte characters", func() {
+			It("should return a failure message with the full text", func() {
+				matcher.Expected = strings.Repeat("é", 30) + "a"
+				selection.TextCall.ReturnText = strings.Repeat("é", 30) + "z"
+				matcher.Match(selection)
+				message := matcher.FailureMessage(selection)
+				Expect(message).To(ContainSubstring("to have text equaling\n    " + strings.Repeat("é", 30) + "a"))
+				Expect(message).To(ContainSubstring("but found\n    " + strings.Repeat("é", 30) + "z"))
+			})
+		})
+
+		Context("when the expected and actual values are not text", func() {
+			It("should not return a text difference", func() {
+				matcher = &ValueMatcher{Method: "Count", Property: "element count", Expected: 2}
+				selection.CountCall.ReturnCount = 3
+				matcher.Match(selection)
+				message := matcher.FailureMessage(selection)
+				Expect(message).To(HavePrefix("Expected selection 'CSS: #selector' to have element count equaling\n"))
+				Expect(message).NotTo(ContainSubstring("<string>"))
+			})
+		})
+	})
+
 	Describe("#NegatedFailureMessage", func() {
 		It("should return a negated failure message with the provided property name", func() {
 			selection.TextCall.ReturnText = "some text"
@@ -7,12 +7,15 @@ import (
 
 // HaveText passes when the expected text is equal to the actual element text.
 // This matcher fails if the provided selection refers to more than one element.
+// When both the expected and actual text are long, the failure message only shows
+// the text surrounding the first difference and marks where they diverge.
 func HaveText(text string) types.GomegaMatcher {
 	return &internal.ValueMatcher{Method: "Text", Property: "text", Expected: text}
 }
 
 // MatchText passes when the expected regular expression matches the actual element text.
 // This matcher will fail if the provided selection refers to more than one element.
+// When the actual text is long, the failure message only shows its beginning and end.
 func MatchText(regexp string) types.GomegaMatcher {
 	return &internal.MatchTextMatcher{Regexp: regexp}
 }
@@ -29,6 +32,12 @@ func HaveAttribute(attribute string, value string) types.GomegaMatcher {
 	return &internal.HaveAttributeMatcher{ExpectedAttribute: attribute, ExpectedValue: value}
 }
 
+// HaveClass passes when the expected CSS class is one of the classes of the element.
+// This matcher will fail if the provided selection refers to more than one element.
+func HaveClass(class string) types.GomegaMatcher {
+	return &internal.HaveClassMatcher{ExpectedClass: class}
+}
+
 // HaveCSS passes when the expected CSS property and value are present on the element.
 // This matcher only matches exact, calculated CSS values, though there is support for parsing colors.
 // Example: "blue" and "#00f" will both match rgba(0, 0, 255, 1)
@@ -66,8 +75,43 @@ func BeFound() types.GomegaMatcher {
 	return &internal.BeFoundMatcher{}
 }
 
+// BePresent passes when the provided selection refers to one or more elements on the page.
+// Unlike BeFound, it relies on Selection.IsPresent, which reports a selection that
+// matches nothing as not present rather than as an error.
+func BePresent() types.GomegaMatcher {
+	return &internal.BePresentMatcher{}
+}
+
 // EqualElement passes when the expected selection refers to the same element as the provided
 // actual selection. This matcher will fail if either selection refers to more than one element.
 func EqualElement(comparable interface{}) types.GomegaMatcher {
 	return &internal.EqualElementMatcher{ExpectedSelection: comparable}
 }
+
+// BeAbove passes when the bottom edge of the actual element is at or above the
+// top edge of the provided selection's element. This matcher will fail if either
+// selection refers to more than one element.
+func BeAbove(other interface{}) types.GomegaMatcher {
+	return &internal.RelativePositionMatcher{Method: "BeAbove", Relation: "above", ExpectedSelection: other}
+}
+
+// BeBelow passes when the top edge of the actual element is at or below the
+// bottom edge of the provided selection's element. This matcher will fail if either
+// selection refers to more than one element.
+func BeBelow(other interface{}) types.GomegaMatcher {
+	return &internal.RelativePositionMatcher{Method: "BeBelow", Relation: "below", ExpectedSelection: other}
+}
+
+// BeLeftOf passes when the right edge of the actual element is at or to the left
+// of the left edge of the provided selection's element. This matcher will fail if
+// either selection refers to more than one element.
+func BeLeftOf(other interface{}) types.GomegaMatcher {
+	return &internal.RelativePositionMatcher{Method: "BeLeftOf", Relation: "left of", ExpectedSelection: other}
+}
+
+// BeRightOf passes when the left edge of the actual element is at or to the right
+// of the right edge of the provided selection's element. This matcher will fail if
+// either selection refers to more than one element.
+func BeRightOf(other interface{}) types.GomegaMatcher {
+	return &internal.RelativePositionMatcher{Method: "BeRightOf", Relation: "right of", ExpectedSelection: other}
+}
@@ -54,6 +54,15 @@ var _ = Describe("Selection Matchers", func() {
 		})
 	})
 
+	Describe("#HaveClass", func() {
+		It("should return a HaveClass matcher", func() {
+			selection.HasClassCall.ReturnHas = true
+			Expect(selection).To(HaveClass("some-class"))
+			selection.HasClassCall.ReturnHas = false
+			Expect(selection).NotTo(HaveClass("some-class"))
+		})
+	})
+
 	Describe("#HaveCSS", func() {
 		It("should return a HaveCSS matcher", func() {
 			selection.CSSCall.ReturnValue = "some value"
@@ -123,6 +132,15 @@ var _ = Describe("Selection Matchers", func() {
 		})
 	})
 
+	Describe("#BePresent", func() {
+		It("should return a BePresent matcher", func() {
+			selection.IsPresentCall.ReturnPresent = true
+			Expect(selection).To(BePresent())
+			selection.IsPresentCall.ReturnPresent = false
+			Expect(selection).NotTo(BePresent())
+		})
+	})
+
 	Describe("#EqualElement", func() {
 		It("should return a EqualElement matcher", func() {
 			selection.EqualsElementCall.ReturnEquals = true
@@ -131,4 +149,33 @@ var _ = Describe("Selection Matchers", func() {
 			Expect(selection).NotTo(EqualElement(selection))
 		})
 	})
+
+	Describe("relative position matchers", func() {
+		var other *mocks.Selection
+
+		BeforeEach(func() {
+			other = &mocks.Selection{}
+			other.RectCall.ReturnX = 100
+			other.RectCall.ReturnY = 100
+			other.RectCall.ReturnWidth = 50
+			other.RectCall.ReturnHeight = 50
+			selection.RectCall.ReturnWidth = 50
+			selection.RectCall.ReturnHeight = 50
+		})
+
+		It("should return matchers comparing the element rects", func() {
+			selection.RectCall.ReturnX, selection.RectCall.ReturnY = 100, 0
+			Expect(selection).To(BeAbove(other))
+			Expect(selection).NotTo(BeBelow(other))
+			selection.RectCall.ReturnX, selection.RectCall.ReturnY = 100, 200
+			Expect(selection).To(BeBelow(other))
+			Expect(selection).NotTo(BeAbove(other))
+			selection.RectCall.ReturnX, selection.RectCall.ReturnY = 0, 100
+			Expect(selection).To(BeLeftOf(other))
+			Expect(selection).NotTo(BeRightOf(other))
+			selection.RectCall.ReturnX, selection.RectCall.ReturnY = 150, 100
+			Expect(selection).To(BeRightOf(other))
+			Expect(selection).NotTo(BeLeftOf(other))
+		})
+	})
 })
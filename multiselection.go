@@ -1,6 +1,12 @@
 package agouti
 
-import "github.com/sclevine/agouti/internal/target"
+import (
+	"fmt"
+	"time"
+
+	"github.com/sclevine/agouti/internal/element"
+	"github.com/sclevine/agouti/internal/target"
+)
 
 // A MultiSelection is a Selection that may be indexed using the At() method.
 // All Selection methods are available on a MultiSelection.
@@ -17,13 +23,266 @@ type MultiSelection struct {
 	Selection
 }
 
-func newMultiSelection(session apiSession, selectors target.Selectors) *MultiSelection {
-	return &MultiSelection{*newSelection(session, selectors)}
+func newMultiSelection(session apiSession, selectors target.Selectors, waits *waitSettings) *MultiSelection {
+	return &MultiSelection{*newSelection(session, selectors, waits)}
 }
 
 // At finds an element at the provided index. It only applies to the immediate selection,
 // meaning that the returned selection may still refer to multiple elements if any parent
 // of the immediate selection is also a *MultiSelection.
+// Negative indices count from the end of the selection, so At(-1) refers to the
+// last element. The concrete index is computed when the selection is used.
 func (s *MultiSelection) At(index int) *Selection {
-	return newSelection(s.session, s.selectors.At(index))
+	return newSelection(s.session, s.selectors.At(index), s.waits)
+}
+
+// AtFromEnd finds an element at the provided index counting back from the
+// end of the selection, so AtFromEnd(0) refers to the last element.
+// It is equivalent to At(-(index + 1)).
+func (s *MultiSelection) AtFromEnd(index int) *Selection {
+	return s.At(-(index + 1))
+}
+
+// Map applies the provided extractor to a selection of each element in the
+// MultiSelection, in order, and returns the extracted values. The elements are
+// only selected once, and the extractor receives a selection that refers
+// directly to each selected element, so it does not follow changes to the DOM.
+// Map stops at the first extractor error and reports the element index.
+func (s *MultiSelection) Map(extractor func(*Selection) (string, error)) ([]string, error) {
+	elements, err := s.elements.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to select elements from %s: %w", s, err)
+	}
+
+	values := []string{}
+	for index, selectedElement := range elements {
+		value, err := extractor(s.selectedAt(index, selectedElement))
+		if err != nil {
+			return nil, fmt.Errorf("failed to map element %d of %s: %w", index, s, err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// selectedAt returns a selection that refers directly to the provided element,
+// which was selected as the element at the provided index of the MultiSelection.
+func (s *MultiSelection) selectedAt(index int, selectedElement element.Element) *Selection {
+	return &Selection{
+		selectable{s.session, s.selectors.At(index), s.waits},
+		&element.Snapshot{Elements: []element.Element{selectedElement}},
+	}
+}
+
+// Any returns true if the provided predicate returns true for a selection of
+// any element in the MultiSelection, ex.
+//    page.All("li").Any(func(item *agouti.Selection) (bool, error) {
+//        text, err := item.Text()
+//        return text == "Milk", err
+//    })
+// The elements are only selected once, and the predicate receives a selection
+// that refers directly to each selected element. Any stops at the first
+// element that satisfies the predicate or at the first predicate error. It
+// returns false if there are no elements.
+func (s *MultiSelection) Any(predicate func(*Selection) (bool, error)) (bool, error) {
+	return s.findMatch(predicate, true)
+}
+
+// Every returns true if the provided predicate returns true for a selection of
+// every element in the MultiSelection. Like Any, the elements are only selected
+// once. Every stops at the first element that does not satisfy the predicate
+// or at the first predicate error. It returns true if there are no elements.
+// Every is named to avoid a clash with All.
+func (s *MultiSelection) Every(predicate func(*Selection) (bool, error)) (bool, error) {
+	mismatch, err := s.findMatch(predicate, false)
+	return !mismatch && err == nil, err
+}
+
+// findMatch returns true as soon as the predicate returns the target result
+// for any element.
+func (s *MultiSelection) findMatch(predicate func(*Selection) (bool, error), target bool) (bool, error) {
+	elements, err := s.elements.Get()
+	if err != nil {
+		return false, fmt.Errorf("failed to select elements from %s: %w", s, err)
+	}
+
+	for index, selectedElement := range elements {
+		result, err := predicate(s.selectedAt(index, selectedElement))
+		if err != nil {
+			return false, fmt.Errorf("failed to evaluate element %d of %s: %w", index, s, err)
+		}
+		if result == target {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// VisibleOrEmpty returns true if the MultiSelection refers to at least one
+// element and all of the elements are visible. Unlike Visible, it returns false
+// without an error when no elements match, so it suits checks such as whether
+// an error banner is showing, where absence means not visible. This includes
+// when a parent selection, such as a Find or At selection, matches nothing.
+// Use Visible when the elements are expected to exist.
+func (s *MultiSelection) VisibleOrEmpty() (bool, error) {
+	elements, err := s.elements.Get()
+	if err != nil {
+		if isNotFoundError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to select elements from %s: %w", s, err)
+	}
+	if len(elements) == 0 {
+		return false, nil
+	}
+	return s.allHaveState(elements, element.Element.IsDisplayed, "visible")
+}
+
+// InOrder returns true if the texts of the elements in the MultiSelection equal
+// the provided texts, in order, ex.
+//    page.All("td.name").InOrder([]string{"Ann", "Bob", "Cy"})
+// When the order does not match, InOrder returns false along with an error that
+// describes the first difference.
+func (s *MultiSelection) InOrder(texts []string) (bool, error) {
+	actual, err := s.texts()
+	if err != nil {
+		return false, err
+	}
+
+	for index := 0; index < len(texts) || index < len(actual); index++ {
+		if index >= len(texts) || index >= len(actual) || texts[index] != actual[index] {
+			return false, fmt.Errorf("texts of '%s' are not in the expected order (first difference at index %d: expected %s, actual %s)\n  expected: %q\n  actual:   %q",
+				s.selectors, index, quotedTextAt(texts, index), quotedTextAt(actual, index), texts, actual)
+		}
+	}
+	return true, nil
+}
+
+// texts returns the text of each element in the MultiSelection, in order.
+func (s *MultiSelection) texts() ([]string, error) {
+	elements, err := s.elements.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to select elements from %s: %w", s, err)
+	}
+
+	texts := []string{}
+	for _, selectedElement := range elements {
+		text, err := selectedElement.GetText()
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve text for %s: %w", s, err)
+		}
+		texts = append(texts, text)
+	}
+	return texts, nil
+}
+
+// CompareTexts compares the texts of the elements in the MultiSelection to the
+// texts of the elements in the provided MultiSelection. Added contains the texts
+// that are only present in the other selection, and removed contains the texts
+// that are only present in this selection. Both are in the order in which the
+// texts first appear, without duplicates, ex.
+//    added, removed, err := page.All("#all li").CompareTexts(page.All("#filtered li"))
+func (s *MultiSelection) CompareTexts(other *MultiSelection) (added, removed []string, err error) {
+	texts, err := s.texts()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	otherTexts, err := other.texts()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return textsNotIn(otherTexts, texts), textsNotIn(texts, otherTexts), nil
+}
+
+// textsNotIn returns the unique texts that are present in texts but absent
+// from excluded, in the order in which they first appear.
+func textsNotIn(texts, excluded []string) []string {
+	seen := map[string]bool{}
+	for _, text := range excluded {
+		seen[text] = true
+	}
+
+	difference := []string{}
+	for _, text := range texts {
+		if !seen[text] {
+			seen[text] = true
+			difference = append(difference, text)
+		}
+	}
+	return difference
+}
+
+func quotedTextAt(texts []string, index int) string {
+	if index >= len(texts) {
+		return "<none>"
+	}
+	return fmt.Sprintf("%q", texts[index])
+}
+
+// CountWithText returns the number of elements in the MultiSelection whose
+// text exactly equals the provided text, ex.
+//    page.All("td.status").CountWithText("Pending")
+func (s *MultiSelection) CountWithText(text string) (int, error) {
+	elements, err := s.elements.Get()
+	if err != nil {
+		return 0, fmt.Errorf("failed to select elements from %s: %w", s, err)
+	}
+
+	count := 0
+	for _, selectedElement := range elements {
+		elementText, err := selectedElement.GetText()
+		if err != nil {
+			return 0, fmt.Errorf("failed to retrieve text for %s: %w", s, err)
+		}
+		if elementText == text {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// WaitUntilCount waits until the selection refers to exactly the expected
+// number of elements. If the timeout elapses first, the returned error includes
+// the last count that was seen.
+func (s *MultiSelection) WaitUntilCount(expected int, timeout time.Duration) error {
+	lastCount, matched := s.waitForCount(timeout, func(count int) bool {
+		return count == expected
+	})
+
+	if !matched {
+		return fmt.Errorf("timed out after %s waiting for '%s' to have %d elements (last: %d)", timeout, s.selectors, expected, lastCount)
+	}
+	return nil
+}
+
+// WaitUntilCountAtLeast waits until the selection refers to at least the
+// provided number of elements. If the timeout elapses first, the returned error
+// includes the last count that was seen.
+func (s *MultiSelection) WaitUntilCountAtLeast(min int, timeout time.Duration) error {
+	lastCount, matched := s.waitForCount(timeout, func(count int) bool {
+		return count >= min
+	})
+
+	if !matched {
+		return fmt.Errorf("timed out after %s waiting for '%s' to have at least %d elements (last: %d)", timeout, s.selectors, min, lastCount)
+	}
+	return nil
+}
+
+// waitForCount polls the number of elements in the selection until it
+// satisfies the provided condition or the timeout elapses. Elements that
+// cannot be found yet are counted as zero.
+func (s *MultiSelection) waitForCount(timeout time.Duration, condition func(count int) bool) (lastCount int, matched bool) {
+	matched = s.waitFor(timeout, func() bool {
+		count, err := s.Count()
+		if err != nil {
+			lastCount = 0
+			return false
+		}
+		lastCount = count
+		return condition(count)
+	})
+	return lastCount, matched
 }
@@ -1,10 +1,14 @@
 package agouti_test
 
 import (
+	"errors"
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/sclevine/agouti"
 	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
 	"github.com/sclevine/agouti/internal/mocks"
 )
 
@@ -32,4 +36,443 @@ var _ = Describe("MultiSelection", func() {
 			Expect(elements[0].ID).To(Equal("some-id"))
 		})
 	})
+
+	Describe("#AtFromEnd", func() {
+		It("should add a negative index counting from the end to the current selection", func() {
+			Expect(selection.AtFromEnd(0).String()).To(Equal("selection 'CSS: #selector [-1]'"))
+			Expect(selection.AtFromEnd(2).String()).To(Equal("selection 'CSS: #selector [-3]'"))
+		})
+
+		It("should select the element counting from the end", func() {
+			bus.SendCall.Result = `[
+				{"element-6066-11e4-a52e-4f735466cecf": "first-id"},
+				{"element-6066-11e4-a52e-4f735466cecf": "last-id"}
+			]`
+			elements, err := selection.AtFromEnd(0).Elements()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(elements[0].ID).To(Equal("last-id"))
+		})
+
+		Context("when the index is out of range", func() {
+			It("should return an error", func() {
+				bus.SendCall.Result = `[{"element-6066-11e4-a52e-4f735466cecf": "some-id"}]`
+				_, err := selection.AtFromEnd(2).Count()
+				Expect(err).To(MatchError("failed to select elements from selection 'CSS: #selector [-3]': index -3 of 1 elements: element index out of range"))
+			})
+		})
+	})
+
+	Describe("#Map", func() {
+		var elementRepository *mocks.ElementRepository
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			elementRepository.GetCall.ReturnElements = []element.Element{&api.Element{}, &api.Element{}}
+			selection = NewTestMultiSelection(session, elementRepository, "#selector")
+		})
+
+		It("should return the extracted value of each indexed element in order", func() {
+			values, err := selection.Map(func(indexed *Selection) (string, error) {
+				return indexed.String(), nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal([]string{
+				"selection 'CSS: #selector [0]'",
+				"selection 'CSS: #selector [1]'",
+			}))
+		})
+
+		It("should provide the extractor with a selection of each selected element", func() {
+			first, second := &mocks.Element{}, &mocks.Element{}
+			first.GetTextCall.ReturnText = "first"
+			second.GetTextCall.ReturnText = "second"
+			elementRepository.GetCall.ReturnElements = []element.Element{first, second}
+			elementRepository.GetExactlyOneCall.Err = errors.New("should not select again")
+			values, err := selection.Map((*Selection).Text)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal([]string{"first", "second"}))
+		})
+
+		Context("when the element repository fails to return the elements", func() {
+			It("should return an error", func() {
+				elementRepository.GetCall.Err = errors.New("some error")
+				_, err := selection.Map(func(*Selection) (string, error) { return "", nil })
+				Expect(err).To(MatchError("failed to select elements from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the extractor fails", func() {
+			It("should stop and return an error with the element index", func() {
+				calls := 0
+				_, err := selection.Map(func(*Selection) (string, error) {
+					calls++
+					return "", errors.New("some error")
+				})
+				Expect(err).To(MatchError("failed to map element 0 of selection 'CSS: #selector': some error"))
+				Expect(calls).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("#Any and #Every", func() {
+		var (
+			elementRepository *mocks.ElementRepository
+			evaluated         []string
+		)
+
+		isSecond := func(item *Selection) (bool, error) {
+			evaluated = append(evaluated, item.String())
+			return item.String() == "selection 'CSS: #selector [1]'", nil
+		}
+
+		isNotSecond := func(item *Selection) (bool, error) {
+			second, err := isSecond(item)
+			return !second, err
+		}
+
+		BeforeEach(func() {
+			evaluated = nil
+			elementRepository = &mocks.ElementRepository{}
+			elementRepository.GetCall.ReturnElements = []element.Element{&api.Element{}, &api.Element{}, &api.Element{}}
+			selection = NewTestMultiSelection(session, elementRepository, "#selector")
+		})
+
+		Describe("#Any", func() {
+			It("should return true and stop at the first element that satisfies the predicate", func() {
+				Expect(selection.Any(isSecond)).To(BeTrue())
+				Expect(evaluated).To(HaveLen(2))
+			})
+
+			It("should return false when no element satisfies the predicate", func() {
+				Expect(selection.Any(func(*Selection) (bool, error) { return false, nil })).To(BeFalse())
+			})
+
+			It("should return false when there are no elements", func() {
+				elementRepository.GetCall.ReturnElements = []element.Element{}
+				Expect(selection.Any(isSecond)).To(BeFalse())
+			})
+		})
+
+		Describe("#Every", func() {
+			It("should return false and stop at the first element that does not satisfy the predicate", func() {
+				Expect(selection.Every(isNotSecond)).To(BeFalse())
+				Expect(evaluated).To(HaveLen(2))
+			})
+
+			It("should return true when every element satisfies the predicate", func() {
+				Expect(selection.Every(func(*Selection) (bool, error) { return true, nil })).To(BeTrue())
+			})
+
+			It("should return true when there are no elements", func() {
+				elementRepository.GetCall.ReturnElements = []element.Element{}
+				Expect(selection.Every(isSecond)).To(BeTrue())
+			})
+		})
+
+		Context("when the element repository fails to return the elements", func() {
+			It("should return an error", func() {
+				elementRepository.GetCall.Err = errors.New("some error")
+				_, err := selection.Every(isSecond)
+				Expect(err).To(MatchError("failed to select elements from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		It("should provide the predicate with a selection of each selected element", func() {
+			milk, eggs := &mocks.Element{}, &mocks.Element{}
+			milk.GetTextCall.ReturnText = "Milk"
+			eggs.GetTextCall.ReturnText = "Eggs"
+			elementRepository.GetCall.ReturnElements = []element.Element{milk, eggs}
+			elementRepository.GetExactlyOneCall.Err = errors.New("should not select again")
+			isEggs := func(item *Selection) (bool, error) {
+				text, err := item.Text()
+				return text == "Eggs", err
+			}
+			Expect(selection.Any(isEggs)).To(BeTrue())
+			Expect(selection.Every(isEggs)).To(BeFalse())
+		})
+
+		Context("when the predicate fails", func() {
+			It("should stop and return an error with the element index", func() {
+				failing := func(*Selection) (bool, error) { return false, errors.New("some error") }
+				anyMatch, err := selection.Any(failing)
+				Expect(anyMatch).To(BeFalse())
+				Expect(err).To(MatchError("failed to evaluate element 0 of selection 'CSS: #selector': some error"))
+				every, err := selection.Every(failing)
+				Expect(every).To(BeFalse())
+				Expect(err).To(MatchError("failed to evaluate element 0 of selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#VisibleOrEmpty", func() {
+		var (
+			elementRepository *mocks.ElementRepository
+			firstElement      *mocks.Element
+			secondElement     *mocks.Element
+		)
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			firstElement, secondElement = &mocks.Element{}, &mocks.Element{}
+			firstElement.IsDisplayedCall.ReturnDisplayed = true
+			secondElement.IsDisplayedCall.ReturnDisplayed = true
+			elementRepository.GetCall.ReturnElements = []element.Element{firstElement, secondElement}
+			selection = NewTestMultiSelection(session, elementRepository, ".error")
+		})
+
+		It("should return true when all elements are visible", func() {
+			Expect(selection.VisibleOrEmpty()).To(BeTrue())
+		})
+
+		It("should return false when any element is not visible", func() {
+			secondElement.IsDisplayedCall.ReturnDisplayed = false
+			Expect(selection.VisibleOrEmpty()).To(BeFalse())
+		})
+
+		Context("when no elements match", func() {
+			It("should return false without an error", func() {
+				elementRepository.GetCall.ReturnElements = []element.Element{}
+				Expect(selection.VisibleOrEmpty()).To(BeFalse())
+			})
+		})
+
+		Context("when a parent selection matches no element", func() {
+			It("should return false without an error", func() {
+				session := &mocks.Session{}
+				session.GetElementsCall.ReturnElements = []*api.Element{}
+				visible, err := NewTestPage(session).Find("#banner-region").All(".error").VisibleOrEmpty()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(visible).To(BeFalse())
+			})
+		})
+
+		Context("when a parent indexed selection is out of range", func() {
+			It("should return false without an error", func() {
+				elementRepository.GetCall.Err = errors.New("element index out of range")
+				Expect(selection.VisibleOrEmpty()).To(BeFalse())
+			})
+		})
+
+		Context("when the elements cannot be selected", func() {
+			It("should return an error", func() {
+				elementRepository.GetCall.Err = errors.New("some error")
+				_, err := selection.VisibleOrEmpty()
+				Expect(err).To(MatchError("failed to select elements from selection 'CSS: .error': some error"))
+			})
+		})
+
+		Context("when the visibility of an element cannot be determined", func() {
+			It("should return an error", func() {
+				secondElement.IsDisplayedCall.Err = errors.New("some error")
+				_, err := selection.VisibleOrEmpty()
+				Expect(err).To(MatchError("failed to determine whether selection 'CSS: .error' is visible: some error"))
+			})
+		})
+	})
+
+	Describe("#InOrder", func() {
+		var elementRepository *mocks.ElementRepository
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			ann, bob := &mocks.Element{}, &mocks.Element{}
+			ann.GetTextCall.ReturnText = "Ann"
+			bob.GetTextCall.ReturnText = "Bob"
+			elementRepository.GetCall.ReturnElements = []element.Element{ann, bob}
+			selection = NewTestMultiSelection(session, elementRepository, "td.name")
+		})
+
+		It("should return true when the element texts are in the provided order", func() {
+			Expect(selection.InOrder([]string{"Ann", "Bob"})).To(BeTrue())
+		})
+
+		Context("when the element texts are in a different order", func() {
+			It("should return false with an error describing the first difference", func() {
+				inOrder, err := selection.InOrder([]string{"Bob", "Ann"})
+				Expect(inOrder).To(BeFalse())
+				Expect(err).To(MatchError("texts of 'CSS: td.name' are not in the expected order (first difference at index 0: expected \"Bob\", actual \"Ann\")\n" +
+					"  expected: [\"Bob\" \"Ann\"]\n" +
+					"  actual:   [\"Ann\" \"Bob\"]"))
+			})
+		})
+
+		Context("when there are fewer elements than expected texts", func() {
+			It("should return false with an error describing the missing text", func() {
+				inOrder, err := selection.InOrder([]string{"Ann", "Bob", "Cy"})
+				Expect(inOrder).To(BeFalse())
+				Expect(err.Error()).To(HavePrefix("texts of 'CSS: td.name' are not in the expected order (first difference at index 2: expected \"Cy\", actual <none>)"))
+			})
+		})
+
+		Context("when the elements cannot be selected", func() {
+			It("should return an error", func() {
+				elementRepository.GetCall.Err = errors.New("some error")
+				_, err := selection.InOrder([]string{"Ann", "Bob"})
+				Expect(err).To(MatchError("failed to select elements from selection 'CSS: td.name': some error"))
+			})
+		})
+
+		Context("when the text of an element cannot be retrieved", func() {
+			It("should return an error", func() {
+				failing := &mocks.Element{}
+				failing.GetTextCall.Err = errors.New("some error")
+				elementRepository.GetCall.ReturnElements = []element.Element{failing}
+				_, err := selection.InOrder([]string{"Ann"})
+				Expect(err).To(MatchError("failed to retrieve text for selection 'CSS: td.name': some error"))
+			})
+		})
+	})
+
+	Describe("#CountWithText", func() {
+		var elementRepository *mocks.ElementRepository
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			first, second, third := &mocks.Element{}, &mocks.Element{}, &mocks.Element{}
+			first.GetTextCall.ReturnText = "Pending"
+			second.GetTextCall.ReturnText = "Done"
+			third.GetTextCall.ReturnText = "Pending"
+			elementRepository.GetCall.ReturnElements = []element.Element{first, second, third}
+			selection = NewTestMultiSelection(session, elementRepository, "td.status")
+		})
+
+		It("should return the number of elements with exactly the provided text", func() {
+			Expect(selection.CountWithText("Pending")).To(Equal(2))
+			Expect(selection.CountWithText("Done")).To(Equal(1))
+			Expect(selection.CountWithText("pending")).To(Equal(0))
+		})
+
+		Context("when the elements cannot be selected", func() {
+			It("should return an error", func() {
+				elementRepository.GetCall.Err = errors.New("some error")
+				_, err := selection.CountWithText("Pending")
+				Expect(err).To(MatchError("failed to select elements from selection 'CSS: td.status': some error"))
+			})
+		})
+
+		Context("when the text of an element cannot be retrieved", func() {
+			It("should return an error", func() {
+				failing := &mocks.Element{}
+				failing.GetTextCall.Err = errors.New("some error")
+				elementRepository.GetCall.ReturnElements = []element.Element{failing}
+				_, err := selection.CountWithText("Pending")
+				Expect(err).To(MatchError("failed to retrieve text for selection 'CSS: td.status': some error"))
+			})
+		})
+	})
+
+	Describe("#CompareTexts", func() {
+		var (
+			elementRepository      *mocks.ElementRepository
+			otherElementRepository *mocks.ElementRepository
+			other                  *MultiSelection
+		)
+
+		elementsWithTexts := func(texts ...string) []element.Element {
+			elements := []element.Element{}
+			for _, text := range texts {
+				textElement := &mocks.Element{}
+				textElement.GetTextCall.ReturnText = text
+				elements = append(elements, textElement)
+			}
+			return elements
+		}
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			otherElementRepository = &mocks.ElementRepository{}
+			elementRepository.GetCall.ReturnElements = elementsWithTexts("Ann", "Bob", "Cy", "Bob", "Di")
+			otherElementRepository.GetCall.ReturnElements = elementsWithTexts("Eve", "Bob", "Di", "Fay", "Eve")
+			selection = NewTestMultiSelection(session, elementRepository, "#before li")
+			other = NewTestMultiSelection(session, otherElementRepository, "#after li")
+		})
+
+		It("should return the unique added and removed texts in the order they first appear", func() {
+			added, removed, err := selection.CompareTexts(other)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(added).To(Equal([]string{"Eve", "Fay"}))
+			Expect(removed).To(Equal([]string{"Ann", "Cy"}))
+		})
+
+		Context("when both selections have the same texts", func() {
+			It("should return no added or removed texts", func() {
+				otherElementRepository.GetCall.ReturnElements = elementsWithTexts("Di", "Cy", "Bob", "Ann")
+				added, removed, err := selection.CompareTexts(other)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(added).To(BeEmpty())
+				Expect(removed).To(BeEmpty())
+			})
+		})
+
+		Context("when the elements cannot be selected", func() {
+			It("should return an error", func() {
+				otherElementRepository.GetCall.Err = errors.New("some error")
+				_, _, err := selection.CompareTexts(other)
+				Expect(err).To(MatchError("failed to select elements from selection 'CSS: #after li': some error"))
+			})
+		})
+
+		Context("when the text of an element cannot be retrieved", func() {
+			It("should return an error", func() {
+				failing := &mocks.Element{}
+				failing.GetTextCall.Err = errors.New("some error")
+				elementRepository.GetCall.ReturnElements = []element.Element{failing}
+				_, _, err := selection.CompareTexts(other)
+				Expect(err).To(MatchError("failed to retrieve text for selection 'CSS: #before li': some error"))
+			})
+		})
+	})
+
+	Describe("#WaitUntilCount", func() {
+		var elementRepository *mocks.ElementRepository
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			elementRepository.GetCall.ReturnElements = []element.Element{&api.Element{}, &api.Element{}}
+			selection = NewTestMultiSelection(session, elementRepository, "#selector")
+			UseFakeClock(selection)
+		})
+
+		It("should successfully return when the selection has the expected number of elements", func() {
+			Expect(selection.WaitUntilCount(2, time.Second)).To(Succeed())
+		})
+
+		Context("when the selection does not have the expected number of elements before the timeout", func() {
+			It("should return an error including the last count", func() {
+				err := selection.WaitUntilCount(1, time.Hour)
+				Expect(err).To(MatchError("timed out after 1h0m0s waiting for 'CSS: #selector' to have 1 elements (last: 2)"))
+			})
+		})
+
+		Context("when the elements cannot be selected", func() {
+			It("should return an error after the timeout", func() {
+				elementRepository.GetCall.Err = errors.New("some error")
+				err := selection.WaitUntilCount(2, 20*time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for 'CSS: #selector' to have 2 elements (last: 0)"))
+			})
+		})
+	})
+
+	Describe("#WaitUntilCountAtLeast", func() {
+		var elementRepository *mocks.ElementRepository
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			elementRepository.GetCall.ReturnElements = []element.Element{&api.Element{}, &api.Element{}}
+			selection = NewTestMultiSelection(session, elementRepository, "#selector")
+			UseFakeClock(selection)
+		})
+
+		It("should successfully return when the selection has at least the provided number of elements", func() {
+			Expect(selection.WaitUntilCountAtLeast(1, time.Second)).To(Succeed())
+			Expect(selection.WaitUntilCountAtLeast(2, time.Second)).To(Succeed())
+		})
+
+		Context("when the selection has too few elements before the timeout", func() {
+			It("should return an error including the last count", func() {
+				err := selection.WaitUntilCountAtLeast(10, 20*time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for 'CSS: #selector' to have at least 10 elements (last: 2)"))
+			})
+		})
+	})
 })
@@ -33,6 +33,10 @@ func Timeout(seconds int) Option {
 }
 
 // ChromeOptions is used to pass additional options to Chrome via ChromeDriver.
+// The options are merged with any Chrome options in the Desired capabilities,
+// such as those set by Capabilities.DownloadDir: nested options like prefs
+// are merged, list options like args are combined, and other options replace
+// the desired value.
 func ChromeOptions(opt string, value interface{}) Option {
 	return func(c *config) {
 		if c.ChromeOptions == nil {
@@ -84,7 +88,8 @@ func (c *config) Capabilities() Capabilities {
 		merged.Browser(c.BrowserName)
 	}
 	if c.ChromeOptions != nil {
-		merged["chromeOptions"] = c.ChromeOptions
+		desiredOptions, _ := merged["chromeOptions"].(map[string]interface{})
+		merged["chromeOptions"] = mergeOptions(desiredOptions, c.ChromeOptions)
 	}
 	if c.RejectInvalidSSL {
 		merged.Without("acceptSslCerts")
@@ -101,5 +101,49 @@ var _ = Describe("Options", func() {
 				Equal(map[string]interface{}{"args": "someArg"}),
 			)
 		})
+
+		It("should merge ChromeOptions into the desired Chrome options", func() {
+			config := NewTestConfig()
+			Desired(NewCapabilities().SetUserAgent("some-agent"))(config)
+			ChromeOptions("args", []string{"--headless"})(config)
+			ChromeOptions("binary", "/some/chrome")(config)
+			Expect(config.Capabilities()["chromeOptions"]).To(Equal(map[string]interface{}{
+				"args":   []interface{}{"--user-agent=some-agent", "--headless"},
+				"binary": "/some/chrome",
+			}))
+		})
+
+		It("should merge ChromeOptions prefs with the desired download directory", func() {
+			config := NewTestConfig()
+			Desired(NewCapabilities().DownloadDir("/some/dir"))(config)
+			ChromeOptions("prefs", map[string]interface{}{"some.pref": true})(config)
+			Expect(config.Capabilities()["chromeOptions"]).To(Equal(map[string]interface{}{
+				"prefs": map[string]interface{}{
+					"download.default_directory":   "/some/dir",
+					"download.prompt_for_download": false,
+					"some.pref":                    true,
+				},
+			}))
+		})
+
+		It("should keep the desired device emulation when ChromeOptions is used", func() {
+			config := NewTestConfig()
+			Desired(NewCapabilities().EmulateDeviceMetrics(360, 640, 3.0, true))(config)
+			ChromeOptions("args", []string{"--headless"})(config)
+			chromeOptions := config.Capabilities()["chromeOptions"].(map[string]interface{})
+			Expect(chromeOptions["args"]).To(Equal([]string{"--headless"}))
+			Expect(chromeOptions["mobileEmulation"]).To(HaveKey("deviceMetrics"))
+		})
+
+		It("should not modify the desired capabilities", func() {
+			config := NewTestConfig()
+			capabilities := NewCapabilities().SetUserAgent("some-agent")
+			Desired(capabilities)(config)
+			ChromeOptions("args", []string{"--headless"})(config)
+			config.Capabilities()
+			Expect(capabilities["chromeOptions"]).To(Equal(map[string]interface{}{
+				"args": []interface{}{"--user-agent=some-agent"},
+			}))
+		})
 	})
 })
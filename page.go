@@ -3,22 +3,33 @@ package agouti
 import (
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
+	"github.com/sclevine/agouti/internal/target"
+	"github.com/sclevine/agouti/key"
 )
 
 // A Page represents an open browser session. Pages may be created using the
 // *WebDriver.Page() method or by calling the NewPage or SauceLabs functions.
 type Page struct {
 	selectable
-	logs map[string][]Log
+	logs             map[string][]Log
+	baseURL          string
+	autoAcceptAlerts bool
+	frameDepth       int
+	traceLogger      io.Writer
 }
 
 // A Log represents a single log message
@@ -41,7 +52,11 @@ type Log struct {
 // method will respect the HTTPClient Option if provided.
 func NewPage(url string, options ...Option) (*Page, error) {
 	pageOptions := config{}.Merge(options)
-	session, err := api.OpenWithClient(url, pageOptions.Capabilities(), pageOptions.HTTPClient)
+	capabilities := pageOptions.Capabilities()
+	if err := capabilities.validate(); err != nil {
+		return nil, fmt.Errorf("invalid capabilities: %s", err)
+	}
+	session, err := api.OpenWithClient(url, capabilities, pageOptions.HTTPClient)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to WebDriver: %s", err)
 	}
@@ -56,8 +71,23 @@ func JoinPage(url string, options ...Option) *Page {
 	return newPage(session)
 }
 
+// AttachToSession creates a Page using an existing session on the WebDriver at
+// the provided URL, without opening a new session. This is useful for
+// inspecting a browser that was started by another process. The session is
+// verified to be alive by retrieving its current URL, and the protocol used by
+// the WebDriver is detected from the response. This method takes Options but
+// respects only the HTTPClient Option if provided.
+func AttachToSession(remoteURL, sessionID string, options ...Option) (*Page, error) {
+	sessionURL := fmt.Sprintf("%s/session/%s", strings.TrimSuffix(remoteURL, "/"), sessionID)
+	page := JoinPage(sessionURL, options...)
+	if _, err := page.session.GetURL(); err != nil {
+		return nil, fmt.Errorf("failed to attach to session '%s': %s", sessionID, err)
+	}
+	return page, nil
+}
+
 func newPage(session *api.Session) *Page {
-	return &Page{selectable{session, nil}, nil}
+	return &Page{selectable: selectable{session, nil, newWaitSettings()}}
 }
 
 // String returns a string representation of the Page. Currently: "page"
@@ -67,10 +97,74 @@ func (p *Page) String() string {
 
 // Session returns a *api.Session that can be used to send direct commands
 // to the WebDriver. See: https://code.google.com/p/selenium/wiki/JsonWireProtocol
+// Use Session().Send to call vendor-specific endpoints that agouti does not support.
 func (p *Page) Session() *api.Session {
 	return p.session.(*api.Session)
 }
 
+// Capabilities returns the capabilities that the WebDriver granted when the
+// session was created, such as the browserName and version that started.
+func (p *Page) Capabilities() (map[string]interface{}, error) {
+	capabilities := p.session.Capabilities()
+	if capabilities == nil {
+		return nil, errors.New("session capabilities unavailable")
+	}
+	return capabilities, nil
+}
+
+// Protocol returns the protocol negotiated with the WebDriver when the
+// session was created, either "w3c" or "jsonwire". An empty string is
+// returned if the protocol is unknown.
+func (p *Page) Protocol() string {
+	return p.session.Protocol()
+}
+
+// ProtocolDialect returns "W3C" or "JSONWire" depending on the shape of the
+// WebDriver response when the session was created. For a page created using
+// JoinPage or AttachToSession, the dialect is determined from the first
+// successful response. An empty string is returned if the dialect is unknown.
+func (p *Page) ProtocolDialect() string {
+	switch p.session.Protocol() {
+	case w3cProtocol:
+		return "W3C"
+	case jsonWireProtocol:
+		return "JSONWire"
+	}
+	return ""
+}
+
+// SetTraceLogger writes each raw WebDriver request and response sent by the
+// page to the provided writer, which is useful for debugging protocol issues:
+//    page.SetTraceLogger(os.Stderr)
+// Cookie values are redacted. Tracing is off by default; pass nil to stop it.
+func (p *Page) SetTraceLogger(logger io.Writer) {
+	p.traceLogger = logger
+	p.session.SetTraceLogger(logger)
+}
+
+// Retry calls fn up to the provided number of attempts, waiting one poll
+// interval between attempts, and returns nil as soon as an attempt succeeds.
+// Otherwise, it returns the error from the last attempt. Each failed attempt
+// is logged to the trace logger set using SetTraceLogger. See agouti.Retry.
+func (p *Page) Retry(attempts int, fn func() error) error {
+	return p.retry(attempts, fn, p.traceLogger)
+}
+
+// EnableMetrics starts recording how many WebDriver requests the page sends to
+// each endpoint and how long they take, which helps find the WebDriver calls
+// that dominate the runtime of a test suite. Metrics are off by default and
+// cost nothing until they are enabled.
+func (p *Page) EnableMetrics() {
+	p.session.EnableMetrics()
+}
+
+// Metrics returns the metrics recorded since EnableMetrics was called, keyed
+// by method and endpoint (ex. "GET element/:id/text"). It returns nil if
+// metrics are not enabled.
+func (p *Page) Metrics() map[string]api.EndpointMetric {
+	return p.session.Metrics()
+}
+
 // Destroy closes any open browsers by ending the session.
 func (p *Page) Destroy() error {
 	if err := p.session.Delete(); err != nil {
@@ -113,12 +207,66 @@ func (p *Page) Reset() error {
 	return p.Navigate("about:blank")
 }
 
-// Navigate navigates to the provided URL.
+// Navigate navigates to the provided URL. If a base URL was set using
+// SetBaseURL, relative URLs are resolved against it.
 func (p *Page) Navigate(url string) error {
-	if err := p.session.SetURL(url); err != nil {
+	resolvedURL, err := p.resolveURL(url)
+	if err != nil {
 		return fmt.Errorf("failed to navigate: %s", err)
 	}
-	return nil
+
+	if err := p.session.SetURL(resolvedURL); err != nil {
+		return fmt.Errorf("failed to navigate: %w", err)
+	}
+	return p.installAlertHandlers()
+}
+
+// NavigateWithRetry navigates to the provided URL like Navigate, but retries
+// up to the provided number of attempts, waiting the provided delay between
+// attempts, when navigation fails due to a network error such as a connection
+// reset. This is useful when the application under test may still be starting.
+// Other errors, such as those reported by the WebDriver for invalid commands,
+// are returned immediately.
+func (p *Page) NavigateWithRetry(url string, attempts int, delay time.Duration) error {
+	if attempts < 1 {
+		return fmt.Errorf("failed to navigate: invalid number of attempts: %d", attempts)
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = p.Navigate(url); err == nil || !isNetworkError(err) {
+			return err
+		}
+		if attempt < attempts {
+			<-p.clock().After(delay)
+		}
+	}
+	return err
+}
+
+// SetBaseURL sets the URL that relative URLs passed to Navigate are resolved
+// against, using the same rules as a browser resolving a link. Absolute URLs
+// are not affected. An empty base URL disables resolution.
+func (p *Page) SetBaseURL(base string) {
+	p.baseURL = base
+}
+
+func (p *Page) resolveURL(rawURL string) (string, error) {
+	if p.baseURL == "" {
+		return rawURL, nil
+	}
+
+	base, err := url.Parse(p.baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL: %s", err)
+	}
+
+	reference, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %s", err)
+	}
+
+	return base.ResolveReference(reference).String(), nil
 }
 
 // GetCookies returns all cookies on the page.
@@ -139,6 +287,7 @@ func (p *Page) GetCookies() ([]*http.Cookie, error) {
 			Secure:   apiCookie.Secure,
 			HttpOnly: apiCookie.HTTPOnly,
 			Expires:  time.Unix(expSeconds, expNano),
+			SameSite: parseSameSite(apiCookie.SameSite),
 		}
 		cookies = append(cookies, cookie)
 	}
@@ -164,6 +313,7 @@ func (p *Page) SetCookie(cookie *http.Cookie) error {
 		Secure:   cookie.Secure,
 		HTTPOnly: cookie.HttpOnly,
 		Expiry:   float64(expiry),
+		SameSite: formatSameSite(cookie.SameSite),
 	}
 
 	if err := p.session.SetCookie(apiCookie); err != nil {
@@ -172,6 +322,30 @@ func (p *Page) SetCookie(cookie *http.Cookie) error {
 	return nil
 }
 
+func formatSameSite(sameSite http.SameSite) string {
+	switch sameSite {
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteNoneMode:
+		return "None"
+	}
+	return ""
+}
+
+func parseSameSite(sameSite string) http.SameSite {
+	switch strings.ToLower(sameSite) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	}
+	return 0
+}
+
 // DeleteCookie deletes a cookie on the page by name.
 func (p *Page) DeleteCookie(name string) error {
 	if err := p.session.DeleteCookie(name); err != nil {
@@ -197,6 +371,31 @@ func (p *Page) URL() (string, error) {
 	return url, nil
 }
 
+// WaitForURL waits until the current page URL matches the provided regular
+// expression. If the timeout elapses first, the returned error includes the
+// last URL that was seen.
+func (p *Page) WaitForURL(pattern string, timeout time.Duration) error {
+	matcher, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid URL pattern: %s", err)
+	}
+
+	var lastURL string
+	matched := p.waitFor(timeout, func() bool {
+		url, err := p.session.GetURL()
+		if err != nil {
+			return false
+		}
+		lastURL = url
+		return matcher.MatchString(url)
+	})
+
+	if !matched {
+		return fmt.Errorf("timed out after %s waiting for URL to match '%s' (last: '%s')", timeout, pattern, lastURL)
+	}
+	return nil
+}
+
 // Size sets the current page size in pixels.
 func (p *Page) Size(width, height int) error {
 	window, err := p.session.GetWindow()
@@ -211,6 +410,168 @@ func (p *Page) Size(width, height int) error {
 	return nil
 }
 
+// Position returns the position of the current window in pixels, relative to
+// the top-left corner of the screen.
+func (p *Page) Position() (x, y int, err error) {
+	window, err := p.session.GetWindow()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to retrieve window: %s", err)
+	}
+
+	x, y, err = window.GetPosition()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to retrieve window position: %s", err)
+	}
+
+	return x, y, nil
+}
+
+// Move moves the current window to the provided position in pixels, relative
+// to the top-left corner of the screen.
+func (p *Page) Move(x, y int) error {
+	window, err := p.session.GetWindow()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve window: %s", err)
+	}
+
+	if err := window.SetPosition(x, y); err != nil {
+		return fmt.Errorf("failed to move window: %s", err)
+	}
+
+	return nil
+}
+
+// WindowRect returns the position and size of the current window in pixels.
+// On W3C WebDrivers, this uses a single request. On other WebDrivers, the
+// position and size are retrieved separately.
+func (p *Page) WindowRect() (x, y, width, height int, err error) {
+	if p.session.Protocol() == w3cProtocol {
+		x, y, width, height, err = p.session.GetWindowRect()
+		if err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("failed to retrieve window rect: %s", err)
+		}
+		return x, y, width, height, nil
+	}
+
+	window, err := p.session.GetWindow()
+	if err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("failed to retrieve window: %s", err)
+	}
+	if x, y, err = window.GetPosition(); err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("failed to retrieve window rect: %s", err)
+	}
+	if width, height, err = window.GetSize(); err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("failed to retrieve window rect: %s", err)
+	}
+	return x, y, width, height, nil
+}
+
+// SetWindowRect moves the current window to the provided position and resizes
+// it to the provided size, in pixels. On W3C WebDrivers, this uses a single
+// request. On other WebDrivers, the window is moved and then resized.
+func (p *Page) SetWindowRect(x, y, width, height int) error {
+	if p.session.Protocol() == w3cProtocol {
+		if err := p.session.SetWindowRect(x, y, width, height); err != nil {
+			return fmt.Errorf("failed to set window rect: %s", err)
+		}
+		return nil
+	}
+
+	window, err := p.session.GetWindow()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve window: %s", err)
+	}
+	if err := window.SetPosition(x, y); err != nil {
+		return fmt.Errorf("failed to set window rect: %s", err)
+	}
+	if err := window.SetSize(width, height); err != nil {
+		return fmt.Errorf("failed to set window rect: %s", err)
+	}
+	return nil
+}
+
+// Fullscreen makes the current window fullscreen, as if the user had
+// requested fullscreen mode from the browser. This uses the W3C "fullscreen
+// window" command, which is not supported by all WebDrivers.
+func (p *Page) Fullscreen() error {
+	if err := p.session.Fullscreen(); err != nil {
+		if isUnsupportedError(err) {
+			return errors.New("failed to enter fullscreen: not supported by this WebDriver")
+		}
+		return fmt.Errorf("failed to enter fullscreen: %s", err)
+	}
+	return nil
+}
+
+// SetNetworkConditions emulates the provided network conditions in Chrome,
+// such as a slow 3G connection. Latency is in milliseconds and throughput is
+// in bytes per second. This uses a ChromeDriver-specific command, so other
+// WebDrivers return an error.
+func (p *Page) SetNetworkConditions(offline bool, latencyMs, downloadBps, uploadBps int) error {
+	if err := p.session.SetNetworkConditions(offline, latencyMs, downloadBps, uploadBps); err != nil {
+		if isUnsupportedError(err) {
+			return errors.New("failed to set network conditions: not supported by this driver")
+		}
+		return fmt.Errorf("failed to set network conditions: %s", err)
+	}
+	return nil
+}
+
+// SetPermission sets the state of the named permission (ex. "geolocation" or
+// "notifications") for the current page's origin, so that the page does not
+// prompt the user for it. The state must be "granted", "denied", or "prompt".
+// This uses the W3C Permissions extension command, which is implemented by
+// ChromeDriver, so some other WebDrivers return an error.
+func (p *Page) SetPermission(name, state string) error {
+	if state != "granted" && state != "denied" && state != "prompt" {
+		return fmt.Errorf(`failed to set permission '%s': invalid state '%s', expected "granted", "denied", or "prompt"`, name, state)
+	}
+
+	if err := p.session.SetPermission(name, state); err != nil {
+		if isUnsupportedError(err) {
+			return fmt.Errorf("failed to set permission '%s': not supported by this WebDriver", name)
+		}
+		return fmt.Errorf("failed to set permission '%s': %s", name, err)
+	}
+	return nil
+}
+
+// ClearCache clears the browser cache, so that subsequent requests fetch
+// assets from the server again. Cookies and storage are not affected. This
+// uses the Chrome DevTools Protocol through a ChromeDriver-specific command,
+// so it is only supported by Chrome and other WebDrivers return an error.
+func (p *Page) ClearCache() error {
+	if err := p.session.ExecuteCDP("Network.clearBrowserCache", nil, nil); err != nil {
+		if isUnsupportedError(err) {
+			return errors.New("failed to clear cache: not supported by this WebDriver")
+		}
+		return fmt.Errorf("failed to clear cache: %s", err)
+	}
+	return nil
+}
+
+// EmulateColorScheme emulates the provided preferred color scheme, either
+// "dark" or "light", so that the page applies the matching
+// prefers-color-scheme media queries. This uses the Chrome DevTools Protocol
+// through a ChromeDriver-specific command, so it is only supported by Chrome
+// and other WebDrivers return an error.
+func (p *Page) EmulateColorScheme(scheme string) error {
+	if scheme != "dark" && scheme != "light" {
+		return fmt.Errorf(`failed to emulate color scheme: invalid scheme '%s', expected "dark" or "light"`, scheme)
+	}
+
+	params := map[string]interface{}{
+		"features": []map[string]string{{"name": "prefers-color-scheme", "value": scheme}},
+	}
+	if err := p.session.ExecuteCDP("Emulation.setEmulatedMedia", params, nil); err != nil {
+		if isUnsupportedError(err) {
+			return errors.New("failed to emulate color scheme: not supported by this WebDriver")
+		}
+		return fmt.Errorf("failed to emulate color scheme: %s", err)
+	}
+	return nil
+}
+
 // Screenshot takes a screenshot and saves it to the provided filename.
 // The provided filename may be an absolute or relative path.
 func (p *Page) Screenshot(filename string) error {
@@ -231,6 +592,46 @@ func (p *Page) Screenshot(filename string) error {
 	return nil
 }
 
+// PrintOptions configures the PDF output of Page.PrintPDF.
+type PrintOptions struct {
+	// Landscape prints the page in landscape rather than portrait orientation.
+	Landscape bool
+
+	// Scale is the page scale factor between 0.1 and 2. Zero uses the
+	// WebDriver default of 1.
+	Scale float64
+
+	// Background prints background colors and images.
+	Background bool
+}
+
+// PrintPDF prints the current page to a PDF and saves it to the provided
+// filename. The file will be saved relative to the current directory if
+// a relative path is provided. Printing is only supported by browsers
+// running in headless mode, such as headless Chrome.
+func (p *Page) PrintPDF(filename string, options PrintOptions) error {
+	absFilePath, err := filepath.Abs(filename)
+	if err != nil {
+		return fmt.Errorf("failed to print page to PDF: %s", err)
+	}
+
+	printOptions := api.PrintOptions{Scale: options.Scale, Background: options.Background}
+	if options.Landscape {
+		printOptions.Orientation = "landscape"
+	}
+
+	pdf, err := p.session.PrintPDF(printOptions)
+	if err != nil {
+		return fmt.Errorf("failed to print page to PDF: %s", err)
+	}
+
+	if err := ioutil.WriteFile(absFilePath, pdf, 0666); err != nil {
+		return fmt.Errorf("failed to print page to PDF: %s", err)
+	}
+
+	return nil
+}
+
 // Title returns the page title.
 func (p *Page) Title() (string, error) {
 	title, err := p.session.GetTitle()
@@ -240,6 +641,28 @@ func (p *Page) Title() (string, error) {
 	return title, nil
 }
 
+// MetaContent returns the content of the single <meta> tag with the provided
+// name, ex.
+//    page.MetaContent("description")
+func (p *Page) MetaContent(name string) (string, error) {
+	return p.metaContent("name", name)
+}
+
+// MetaProperty returns the content of the single <meta> tag with the provided
+// property, such as an Open Graph property, ex.
+//    page.MetaProperty("og:title")
+func (p *Page) MetaProperty(property string) (string, error) {
+	return p.metaContent("property", property)
+}
+
+func (p *Page) metaContent(attribute, value string) (string, error) {
+	content, err := p.Find(fmt.Sprintf("head meta[%s=%s]", attribute, target.CSSString(value))).Attribute("content")
+	if err != nil {
+		return "", fmt.Errorf("failed to read meta '%s': %s", value, err)
+	}
+	return content, nil
+}
+
 // HTML returns the current contents of the DOM for the entire page.
 func (p *Page) HTML() (string, error) {
 	html, err := p.session.GetSource()
@@ -258,6 +681,17 @@ func (p *Page) HTML() (string, error) {
 //    page.RunScript("return test;", map[string]interface{}{"test": 100}, &number)
 //    fmt.Println(number)
 // -> 100
+//
+// If the body returns a DOM element or an array of DOM elements, the result
+// argument may be a **Selection or a *[]*Selection to receive selections of
+// the returned elements:
+//    var button *Selection
+//    page.RunScript("return document.querySelector('form').elements[0];", nil, &button)
+//    button.Click()
+// Nested arrays of elements, such as the cells of each table row, may be
+// received using nested slices, such as a *[][]*Selection, at any depth.
+// These selections refer directly to the returned elements, so they do not
+// follow changes to the DOM and cannot be refined using Find, All, etc.
 func (p *Page) RunScript(body string, arguments map[string]interface{}, result interface{}) error {
 	var (
 		keys   []string
@@ -272,6 +706,27 @@ func (p *Page) RunScript(body string, arguments map[string]interface{}, result i
 	argumentList := strings.Join(keys, ", ")
 	cleanBody := fmt.Sprintf("return (function(%s) { %s; }).apply(this, arguments);", argumentList, body)
 
+	switch result.(type) {
+	case **Selection, *[]*Selection:
+		elements, err := p.session.ExecuteElements(cleanBody, values)
+		if err != nil {
+			return fmt.Errorf("failed to run script: %s", err)
+		}
+		return p.scriptSelections(elements, result)
+	}
+
+	if isNestedSelections(result) {
+		tree, err := p.session.ExecuteNestedElements(cleanBody, values)
+		if err != nil {
+			return fmt.Errorf("failed to run script: %s", err)
+		}
+		index := 0
+		if err := p.nestedScriptSelections(tree, reflect.ValueOf(result).Elem(), &index); err != nil {
+			return fmt.Errorf("failed to run script: %s", err)
+		}
+		return nil
+	}
+
 	if err := p.session.Execute(cleanBody, values, result); err != nil {
 		return fmt.Errorf("failed to run script: %s", err)
 	}
@@ -279,6 +734,207 @@ func (p *Page) RunScript(body string, arguments map[string]interface{}, result i
 	return nil
 }
 
+// scriptSelections stores selections of elements returned by a script into a
+// **Selection or *[]*Selection destination.
+func (p *Page) scriptSelections(elements []*api.Element, destination interface{}) error {
+	switch destination := destination.(type) {
+	case **Selection:
+		if len(elements) != 1 {
+			return fmt.Errorf("failed to run script: expected one element but script returned %d", len(elements))
+		}
+		selector := target.Selector{Type: target.Script, Single: true}
+		*destination = p.scriptSelection(selector, elements[0])
+	case *[]*Selection:
+		selections := []*Selection{}
+		for index, selectedElement := range elements {
+			selector := target.Selector{Type: target.Script, Index: index, Indexed: true}
+			selections = append(selections, p.scriptSelection(selector, selectedElement))
+		}
+		*destination = selections
+	}
+	return nil
+}
+
+// isNestedSelections returns true if the destination is a pointer to nested
+// slices of selections, such as a *[][]*Selection.
+func isNestedSelections(destination interface{}) bool {
+	destinationType := reflect.TypeOf(destination)
+	if destinationType == nil || destinationType.Kind() != reflect.Ptr {
+		return false
+	}
+
+	depth := 0
+	elementType := destinationType.Elem()
+	for ; elementType.Kind() == reflect.Slice; depth++ {
+		elementType = elementType.Elem()
+	}
+	return depth > 1 && elementType == reflect.TypeOf(&Selection{})
+}
+
+// nestedScriptSelections stores selections of the elements in a tree returned
+// by a script into the provided nested slice. Elements are indexed in the order
+// they appear in the tree.
+func (p *Page) nestedScriptSelections(tree interface{}, destination reflect.Value, index *int) error {
+	if tree == nil {
+		destination.Set(reflect.MakeSlice(destination.Type(), 0, 0))
+		return nil
+	}
+
+	items, ok := tree.([]interface{})
+	if !ok {
+		return errors.New("expected an array but script returned an element")
+	}
+
+	slice := reflect.MakeSlice(destination.Type(), len(items), len(items))
+	for itemIndex, item := range items {
+		if slice.Index(itemIndex).Kind() == reflect.Slice {
+			if err := p.nestedScriptSelections(item, slice.Index(itemIndex), index); err != nil {
+				return err
+			}
+			continue
+		}
+
+		selectedElement, ok := item.(*api.Element)
+		if !ok {
+			return errors.New("expected an element but script returned an array or null")
+		}
+		selector := target.Selector{Type: target.Script, Index: *index, Indexed: true}
+		slice.Index(itemIndex).Set(reflect.ValueOf(p.scriptSelection(selector, selectedElement)))
+		*index++
+	}
+	destination.Set(slice)
+	return nil
+}
+
+func (p *Page) scriptSelection(selector target.Selector, selectedElement *api.Element) *Selection {
+	return &Selection{
+		selectable{p.session, target.Selectors{selector}, p.waits},
+		&element.Snapshot{Elements: []element.Element{selectedElement}},
+	}
+}
+
+// WaitForScript repeatedly runs the provided JavaScript predicate until it
+// returns true, ex.
+//    page.WaitForScript("return window.appReady === true;", 5*time.Second)
+// An error is returned if the timeout elapses first. Errors running the
+// predicate are returned immediately instead of being treated as not ready.
+func (p *Page) WaitForScript(predicate string, timeout time.Duration) error {
+	ready, err := p.waitForScript(predicate, timeout)
+	if err != nil {
+		return err
+	}
+
+	if !ready {
+		return fmt.Errorf("timed out after %s waiting for script condition", timeout)
+	}
+	return nil
+}
+
+const ajaxCompleteScript = `return typeof window.jQuery === "undefined" || window.jQuery.active === 0;`
+
+// WaitForAjax waits until all jQuery AJAX requests on the page have completed.
+// If the page does not use jQuery, WaitForAjax returns immediately. An error is
+// returned if the timeout elapses first or if the check itself fails.
+func (p *Page) WaitForAjax(timeout time.Duration) error {
+	complete, err := p.waitForScript(ajaxCompleteScript, timeout)
+	if err != nil {
+		return err
+	}
+
+	if !complete {
+		return fmt.Errorf("timed out after %s waiting for AJAX to complete", timeout)
+	}
+	return nil
+}
+
+// WaitForDownload waits until a file matching filenameGlob (ex. "report-*.csv")
+// appears in dir and returns its path. Partially downloaded files ending in
+// ".crdownload" (Chrome) or ".part" (Firefox) are ignored, as are files that
+// still have such a partial download alongside them, so the returned file has
+// finished downloading. Matching files that already exist when the wait starts
+// are ignored unless they are modified during the wait. Use
+// Capabilities.DownloadDir or FirefoxProfile to configure where downloads are
+// saved. An error is returned if the timeout
+// elapses first or if the glob is malformed.
+func (p *Page) WaitForDownload(dir, filenameGlob string, timeout time.Duration) (string, error) {
+	pattern := filepath.Join(dir, filenameGlob)
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return "", fmt.Errorf("failed to wait for download matching '%s': %s", filenameGlob, err)
+	}
+
+	existing := downloadModTimes(pattern)
+	var download string
+	found := p.waitFor(timeout, func() bool {
+		matches, _ := filepath.Glob(pattern)
+		for _, match := range matches {
+			if isPartialDownload(match) {
+				continue
+			}
+			info, err := os.Stat(match)
+			if err != nil || info.IsDir() {
+				continue
+			}
+			if modTime, ok := existing[match]; ok && info.ModTime().Equal(modTime) {
+				continue
+			}
+			download = match
+			return true
+		}
+		return false
+	})
+
+	if !found {
+		return "", fmt.Errorf("timed out after %s waiting for download matching '%s' in '%s'", timeout, filenameGlob, dir)
+	}
+	return download, nil
+}
+
+var partialDownloadSuffixes = []string{".crdownload", ".part"}
+
+// isPartialDownload returns true if the file is a partial download, or if a
+// partial download of the file is still in progress.
+func downloadModTimes(pattern string) map[string]time.Time {
+	modTimes := map[string]time.Time{}
+	matches, _ := filepath.Glob(pattern)
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil {
+			modTimes[match] = info.ModTime()
+		}
+	}
+	return modTimes
+}
+
+func isPartialDownload(filename string) bool {
+	for _, suffix := range partialDownloadSuffixes {
+		if strings.HasSuffix(filename, suffix) {
+			return true
+		}
+		if _, err := os.Stat(filename + suffix); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// waitForScript runs the provided script body until it returns true or the
+// timeout elapses. Errors running the script are returned immediately.
+func (p *Page) waitForScript(body string, timeout time.Duration) (bool, error) {
+	var scriptErr error
+	matched := p.waitFor(timeout, func() bool {
+		var result bool
+		if err := p.RunScript(body, nil, &result); err != nil {
+			scriptErr = err
+			return true
+		}
+		return result
+	})
+
+	if scriptErr != nil {
+		return false, scriptErr
+	}
+	return matched, nil
+}
+
 // PopupText returns the current alert, confirm, or prompt popup text.
 func (p *Page) PopupText() (string, error) {
 	text, err := p.session.GetAlertText()
@@ -312,12 +968,157 @@ func (p *Page) CancelPopup() error {
 	return nil
 }
 
+// IsAlertPresent returns true if an alert, confirm, or prompt popup is
+// currently open. The popup is left open.
+func (p *Page) IsAlertPresent() (bool, error) {
+	if _, err := p.session.GetAlertText(); err != nil {
+		if isNoAlertError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to determine whether an alert is present: %s", err)
+	}
+	return true, nil
+}
+
+const (
+	installAlertHandlersScript = `
+if (!window.__agoutiNativeDialogs) {
+	window.__agoutiNativeDialogs = {alert: window.alert, confirm: window.confirm, prompt: window.prompt};
+}
+window.alert = function() {};
+window.confirm = function() { return true; };
+window.prompt = function(message, value) { return value === undefined ? "" : value; };`
+
+	restoreAlertHandlersScript = `
+var dialogs = window.__agoutiNativeDialogs;
+if (dialogs) {
+	window.alert = dialogs.alert;
+	window.confirm = dialogs.confirm;
+	window.prompt = dialogs.prompt;
+	delete window.__agoutiNativeDialogs;
+}`
+)
+
+// AutoAcceptAlerts enables or disables automatically accepting alert, confirm,
+// and prompt popups. When enabled, window.alert, window.confirm, and
+// window.prompt are replaced with JavaScript stubs on the current document and
+// again after each Navigate, Forward, Back, and Refresh. Confirm popups are
+// accepted and prompt popups return their default value.
+//
+// Because the native dialogs are replaced rather than handled, popups opened
+// before the stubs are installed (ex. during page load), popups opened by the
+// browser itself, and navigations not made by Page methods are not affected.
+func (p *Page) AutoAcceptAlerts(enabled bool) error {
+	p.autoAcceptAlerts = enabled
+	if enabled {
+		return p.installAlertHandlers()
+	}
+
+	if err := p.session.Execute(restoreAlertHandlersScript, nil, nil); err != nil {
+		return fmt.Errorf("failed to restore alert handlers: %s", err)
+	}
+	return nil
+}
+
+func (p *Page) installAlertHandlers() error {
+	if !p.autoAcceptAlerts {
+		return nil
+	}
+
+	if err := p.session.Execute(installAlertHandlersScript, nil, nil); err != nil {
+		return fmt.Errorf("failed to install alert handlers: %s", err)
+	}
+	return nil
+}
+
+// SendKeys types the provided keys into the page without targeting a specific
+// element, which is useful for testing global keyboard shortcuts. Special keys
+// are available in the key package, ex.
+//    page.SendKeys(key.Control, "k")
+func (p *Page) SendKeys(keys ...string) error {
+	if err := p.session.Keys(strings.Join(keys, "")); err != nil {
+		return fmt.Errorf("failed to send keys: %s", err)
+	}
+	return nil
+}
+
+const focusBodyScript = `
+if (document.activeElement) {
+	document.activeElement.blur();
+}
+document.body.focus();`
+
+// TabOrder returns true if pressing Tab from the top of the page focuses each
+// of the provided selections in order, ex.
+//    page.TabOrder([]*agouti.Selection{page.Find("#name"), page.Find("#email"), page.FindByButton("Save")})
+// Each selection must refer to exactly one element, which is compared to the
+// active element after each Tab. If a different element is focused, TabOrder
+// returns false along with an error that names the step and the focused element.
+// On W3C WebDrivers, Tab is sent to the active element.
+func (p *Page) TabOrder(selections []*Selection) (bool, error) {
+	if err := p.session.Execute(focusBodyScript, nil, nil); err != nil {
+		return false, fmt.Errorf("failed to focus page body: %s", err)
+	}
+
+	for index, selection := range selections {
+		if err := p.pressTab(); err != nil {
+			return false, fmt.Errorf("failed to press tab: %s", err)
+		}
+
+		selectedElement, err := selection.elements.GetExactlyOne()
+		if err != nil {
+			return false, fmt.Errorf("failed to select element from %s: %s", selection, err)
+		}
+
+		activeElement, err := p.session.GetActiveElement()
+		if err != nil {
+			return false, fmt.Errorf("failed to retrieve active element: %s", err)
+		}
+
+		equal, err := selectedElement.IsEqualTo(activeElement)
+		if err != nil {
+			return false, fmt.Errorf("failed to compare %s to active element: %s", selection, err)
+		}
+		if !equal {
+			return false, fmt.Errorf("tab order mismatch at step %d: expected %s to be focused, but %s is focused", index+1, selection, p.describeElement(activeElement))
+		}
+	}
+	return true, nil
+}
+
+const openingTagScript = `
+var html = arguments[0].outerHTML;
+return html.slice(0, html.indexOf('>') + 1);`
+
+// describeElement returns the opening tag of the provided element, or its
+// WebDriver ID if the tag cannot be read.
+func (p *Page) describeElement(selectedElement *api.Element) string {
+	var tag string
+	arguments := []interface{}{elementArgument(selectedElement)}
+	if err := p.session.Execute(openingTagScript, arguments, &tag); err != nil || tag == "" {
+		return fmt.Sprintf("element '%s'", selectedElement.ID)
+	}
+	return tag
+}
+
+func (p *Page) pressTab() error {
+	if p.session.Protocol() != w3cProtocol {
+		return p.session.Keys(key.Tab)
+	}
+
+	activeElement, err := p.session.GetActiveElement()
+	if err != nil {
+		return err
+	}
+	return activeElement.Value(key.Tab)
+}
+
 // Forward navigates forward in history.
 func (p *Page) Forward() error {
 	if err := p.session.Forward(); err != nil {
 		return fmt.Errorf("failed to navigate forward in history: %s", err)
 	}
-	return nil
+	return p.installAlertHandlers()
 }
 
 // Back navigates backwards in history.
@@ -325,7 +1126,7 @@ func (p *Page) Back() error {
 	if err := p.session.Back(); err != nil {
 		return fmt.Errorf("failed to navigate backwards in history: %s", err)
 	}
-	return nil
+	return p.installAlertHandlers()
 }
 
 // Refresh refreshes the page.
@@ -333,7 +1134,31 @@ func (p *Page) Refresh() error {
 	if err := p.session.Refresh(); err != nil {
 		return fmt.Errorf("failed to refresh page: %s", err)
 	}
-	return nil
+	return p.installAlertHandlers()
+}
+
+// RefreshAndWait refreshes the page and waits until the reloaded document has
+// finished loading (document.readyState is "complete"). An error is returned
+// if the page does not finish loading before the timeout elapses.
+func (p *Page) RefreshAndWait(timeout time.Duration) error {
+	if err := p.session.Execute(setNavigationMarkerScript, nil, nil); err != nil {
+		return fmt.Errorf("failed to mark current document: %s", err)
+	}
+
+	if err := p.session.Refresh(); err != nil {
+		return fmt.Errorf("failed to refresh page: %s", err)
+	}
+
+	reloaded := p.waitFor(timeout, func() bool {
+		var reloaded bool
+		err := p.session.Execute(reloadedScript, nil, &reloaded)
+		return err == nil && reloaded
+	})
+
+	if !reloaded {
+		return fmt.Errorf("timed out after %s waiting for page to load after refresh", timeout)
+	}
+	return p.installAlertHandlers()
 }
 
 // SwitchToParentFrame focuses on the immediate parent frame of a frame selected
@@ -359,6 +1184,48 @@ func (p *Page) SwitchToRootFrame() error {
 	return nil
 }
 
+// WithinFrame focuses on the frame referred to by the provided selection, calls
+// fn, and then restores focus to the root frame, even if fn returns an error.
+// Selections made within fn refer to the frame. WithinFrame may be nested, in
+// which case the inner call restores focus to its parent frame instead.
+// Any error from fn takes precedence over an error restoring focus.
+func (p *Page) WithinFrame(frame *Selection, fn func() error) (err error) {
+	if err := frame.SwitchToFrame(); err != nil {
+		return err
+	}
+
+	p.frameDepth++
+	defer func() {
+		p.frameDepth--
+		restore := p.SwitchToParentFrame
+		if p.frameDepth == 0 {
+			restore = p.SwitchToRootFrame
+		}
+		if restoreErr := restore(); err == nil {
+			err = restoreErr
+		}
+	}()
+
+	return fn()
+}
+
+// Frames returns a MultiSelection referring to all iframe and frame elements in
+// the currently focused frame. It is equivalent to page.All("iframe, frame").
+// Combine it with WithinFrame to check the content of each frame, ex.
+//    count, _ := page.FrameCount()
+//    for i := 0; i < count; i++ {
+//        page.WithinFrame(page.Frames().At(i), func() error { ... })
+//    }
+func (p *Page) Frames() *MultiSelection {
+	return p.All("iframe, frame")
+}
+
+// FrameCount returns the number of iframe and frame elements in the currently
+// focused frame. It is equivalent to page.Frames().Count().
+func (p *Page) FrameCount() (int, error) {
+	return p.Frames().Count()
+}
+
 // SwitchToWindow switches to the first available window with the provided name
 // (JavaScript `window.name` attribute).
 func (p *Page) SwitchToWindow(name string) error {
@@ -368,6 +1235,72 @@ func (p *Page) SwitchToWindow(name string) error {
 	return nil
 }
 
+// SwitchToWindowByTitle switches to the first available window with a title
+// equal to the provided title. Each window is checked in turn. If no window
+// matches or a window cannot be checked, focus is returned to the original
+// window and an error is returned.
+func (p *Page) SwitchToWindowByTitle(title string) error {
+	return p.switchToWindowWhere(fmt.Sprintf("title '%s'", title), func() (bool, error) {
+		windowTitle, err := p.Title()
+		return windowTitle == title, err
+	})
+}
+
+// SwitchToWindowByURL switches to the first available window with a URL that
+// matches the provided regular expression. Each window is checked in turn. If
+// no window matches or a window cannot be checked, focus is returned to the
+// original window and an error is returned.
+func (p *Page) SwitchToWindowByURL(pattern string) error {
+	matcher, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid URL pattern: %s", err)
+	}
+
+	return p.switchToWindowWhere(fmt.Sprintf("URL matching '%s'", pattern), func() (bool, error) {
+		url, err := p.URL()
+		return matcher.MatchString(url), err
+	})
+}
+
+func (p *Page) switchToWindowWhere(description string, matches func() (bool, error)) error {
+	windows, err := p.session.GetWindows()
+	if err != nil {
+		return fmt.Errorf("failed to find available windows: %s", err)
+	}
+
+	activeWindow, err := p.session.GetWindow()
+	if err != nil {
+		return fmt.Errorf("failed to find active window: %s", err)
+	}
+
+	for _, window := range windows {
+		if err := p.session.SetWindow(window); err != nil {
+			return p.restoreWindow(activeWindow, fmt.Errorf("failed to switch to window: %s", err))
+		}
+		matched, err := matches()
+		if err != nil {
+			return p.restoreWindow(activeWindow, fmt.Errorf("failed to switch to window with %s: %s", description, err))
+		}
+		if matched {
+			return nil
+		}
+	}
+
+	if err := p.session.SetWindow(activeWindow); err != nil {
+		return fmt.Errorf("failed to switch to original window: %s", err)
+	}
+	return fmt.Errorf("failed to find window with %s", description)
+}
+
+// restoreWindow switches back to the provided window after the provided error
+// occurred, and reports any failure to switch back along with that error.
+func (p *Page) restoreWindow(window *api.Window, err error) error {
+	if restoreErr := p.session.SetWindow(window); restoreErr != nil {
+		return fmt.Errorf("%s (failed to switch to original window: %s)", err, restoreErr)
+	}
+	return err
+}
+
 // NextWindow switches to the next available window.
 func (p *Page) NextWindow() error {
 	windows, err := p.session.GetWindows()
@@ -518,6 +1451,42 @@ func (p *Page) Click(event Click, button Button) error {
 	return nil
 }
 
+// SetPollInterval sets how often methods that wait for a condition check
+// whether the condition has been met. It applies to the page and to all new
+// and existing selections created from the page. The default interval is
+// 100ms. Intervals shorter than 10ms are raised to 10ms to avoid flooding
+// the WebDriver (especially a remote one) with requests.
+func (p *Page) SetPollInterval(interval time.Duration) {
+	if interval < minPollInterval {
+		interval = minPollInterval
+	}
+	p.waits.pollInterval = interval
+}
+
+// SetDoubleClickStabilityTimeout makes DoubleClick wait until each element stops
+// moving before double-clicking on it, which avoids double-clicking on different
+// targets while an element is animating. An element is considered stable once
+// two consecutive reads of its position and size, one poll interval apart, are
+// equal. DoubleClick fails if an element is still moving when the timeout
+// elapses. It applies to the page and to all new and existing selections
+// created from the page. A zero timeout, the default, disables the check.
+func (p *Page) SetDoubleClickStabilityTimeout(timeout time.Duration) {
+	p.waits.stabilityTimeout = timeout
+}
+
+// SetStaleElementRetries sets how many times Text, Attribute, CSS and Value
+// select their element again when the selected element goes stale while its
+// value is being read. Like Retry, each attempt waits one poll interval after
+// the previous attempt. It applies to the page and to all new and existing
+// selections created from the page. The default is one retry, and a negative
+// number of retries is treated as zero.
+func (p *Page) SetStaleElementRetries(retries int) {
+	if retries < 0 {
+		retries = 0
+	}
+	p.waits.staleElementRetries = retries
+}
+
 // SetImplicitWait sets the implicit wait timeout (in ms)
 func (p *Page) SetImplicitWait(timeout int) error {
 	return p.session.SetImplicitWait(timeout)
@@ -528,6 +1497,29 @@ func (p *Page) SetPageLoad(timeout int) error {
 	return p.session.SetPageLoad(timeout)
 }
 
+// SetTimeouts sets the implicit wait, page load, and script timeouts using a
+// single request. Zero timeouts are left unchanged. Durations are rounded up
+// to the nearest millisecond, so that a non-zero duration is never left
+// unchanged.
+func (p *Page) SetTimeouts(implicit, pageLoad, script time.Duration) error {
+	timeouts := api.Timeouts{
+		Implicit: timeoutMilliseconds(implicit),
+		PageLoad: timeoutMilliseconds(pageLoad),
+		Script:   timeoutMilliseconds(script),
+	}
+	if err := p.session.SetTimeouts(timeouts); err != nil {
+		return fmt.Errorf("failed to set timeouts: %s", err)
+	}
+	return nil
+}
+
+func timeoutMilliseconds(timeout time.Duration) int {
+	if timeout <= 0 {
+		return 0
+	}
+	return int((timeout + time.Millisecond - 1) / time.Millisecond)
+}
+
 // SetScriptTimeout sets the script timeout (in ms)
 func (p *Page) SetScriptTimeout(timeout int) error {
 	return p.session.SetScriptTimeout(timeout)
@@ -0,0 +1,50 @@
+package agouti
+
+// A PageObject is a base for page objects that represent a section of a page,
+// such as a form or a dialog. It embeds the *Page, so the full page API is
+// available, and scopes CSS selections to a root element using Within.
+//
+// Page objects should embed a PageObject and define methods in terms of it:
+//    type LoginForm struct {
+//        agouti.PageObject
+//    }
+//
+//    func NewLoginForm(page *agouti.Page) *LoginForm {
+//        return &LoginForm{agouti.NewPageObject(page, "form#login")}
+//    }
+//
+//    func (f *LoginForm) LogIn(email, password string) error {
+//        if err := f.Within().Find("#email").Fill(email); err != nil {
+//            return err
+//        }
+//        if err := f.Within().Find("#password").Fill(password); err != nil {
+//            return err
+//        }
+//        return f.Within().Find("button[type=submit]").Click()
+//    }
+// Methods that are not scoped, such as Navigate or Title, apply to the entire
+// page as usual.
+//
+// Only finders that use CSS selectors, link text, or relative XPath (ex. Find,
+// All, First, FindByID, FindByClass, FindByName, FindByTestID, FindByLink, and
+// FindByRole) are scoped to the root element. FindByLabel and FindByButton use
+// absolute XPath expressions, as may FindByXPath, so they match elements
+// anywhere on the page even when called on Within.
+type PageObject struct {
+	*Page
+	root *Selection
+}
+
+// NewPageObject returns a PageObject for the provided page that is scoped to
+// the single element matching the provided CSS root selector.
+func NewPageObject(page *Page, rootSelector string) PageObject {
+	return PageObject{Page: page, root: page.Find(rootSelector)}
+}
+
+// Within returns a selection of the page object's root element. CSS and link
+// text selections made from it only match elements within the root element,
+// ex.
+//    form.Within().Find("#email")
+func (o PageObject) Within() *Selection {
+	return o.root
+}
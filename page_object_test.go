@@ -0,0 +1,38 @@
+package agouti_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("PageObject", func() {
+	var (
+		session    *mocks.Session
+		page       *Page
+		pageObject PageObject
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		page = NewTestPage(session)
+		pageObject = NewPageObject(page, "form#login")
+	})
+
+	It("should embed the provided page", func() {
+		Expect(pageObject.Page).To(BeIdenticalTo(page))
+		session.GetTitleCall.ReturnTitle = "Log In"
+		Expect(pageObject.Title()).To(Equal("Log In"))
+	})
+
+	Describe("#Within", func() {
+		It("should return a selection of the root element", func() {
+			Expect(pageObject.Within().String()).To(Equal("selection 'CSS: form#login [single]'"))
+		})
+
+		It("should scope selections to the root element", func() {
+			Expect(pageObject.Within().Find("#email").String()).To(Equal("selection 'CSS: form#login [single] | CSS: #email [single]'"))
+		})
+	})
+})
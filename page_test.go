@@ -1,11 +1,16 @@
 package agouti_test
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"syscall"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -14,6 +19,7 @@ import (
 	"github.com/sclevine/agouti/api"
 	. "github.com/sclevine/agouti/internal/matchers"
 	"github.com/sclevine/agouti/internal/mocks"
+	"github.com/sclevine/agouti/key"
 )
 
 var _ = Describe("Page", func() {
@@ -27,6 +33,48 @@ var _ = Describe("Page", func() {
 		page = NewTestPage(session)
 	})
 
+	Describe("AttachToSession", func() {
+		var (
+			server       *httptest.Server
+			requestPath  string
+			responseCode int
+		)
+
+		BeforeEach(func() {
+			requestPath, responseCode = "", 200
+			server = httptest.NewServer(http.HandlerFunc(func(response http.ResponseWriter, request *http.Request) {
+				requestPath = request.URL.Path
+				response.WriteHeader(responseCode)
+				response.Write([]byte(`{"value": "http://example.com"}`))
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should return a page for the existing session after verifying that it is alive", func() {
+			page, err := AttachToSession(server.URL+"/", "some-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requestPath).To(Equal("/session/some-id/url"))
+			Expect(page.Session().Bus).NotTo(BeNil())
+		})
+
+		It("should detect the protocol used by the WebDriver", func() {
+			page, err := AttachToSession(server.URL, "some-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.Protocol()).To(Equal("w3c"))
+		})
+
+		Context("when the session is no longer alive", func() {
+			It("should return an error", func() {
+				responseCode = 404
+				_, err := AttachToSession(server.URL, "some-id")
+				Expect(err).To(MatchError(HavePrefix("failed to attach to session 'some-id': request unsuccessful")))
+			})
+		})
+	})
+
 	Describe("#String", func() {
 		It("should return 'page'", func() {
 			Expect(page.String()).To(Equal("page"))
@@ -41,6 +89,116 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#Capabilities", func() {
+		It("should return the capabilities granted when the session was created", func() {
+			session.CapabilitiesCall.ReturnCapabilities = map[string]interface{}{"browserName": "chrome"}
+			Expect(page.Capabilities()).To(Equal(map[string]interface{}{"browserName": "chrome"}))
+		})
+
+		Context("when the session capabilities are unknown", func() {
+			It("should return an error", func() {
+				_, err := page.Capabilities()
+				Expect(err).To(MatchError("session capabilities unavailable"))
+			})
+		})
+	})
+
+	Describe("#Protocol", func() {
+		It("should return the protocol negotiated when the session was created", func() {
+			session.ProtocolCall.ReturnProtocol = "w3c"
+			Expect(page.Protocol()).To(Equal("w3c"))
+		})
+	})
+
+	Describe("#ProtocolDialect", func() {
+		It("should return W3C for W3C sessions", func() {
+			session.ProtocolCall.ReturnProtocol = "w3c"
+			Expect(page.ProtocolDialect()).To(Equal("W3C"))
+		})
+
+		It("should return JSONWire for JSON Wire Protocol sessions", func() {
+			session.ProtocolCall.ReturnProtocol = "jsonwire"
+			Expect(page.ProtocolDialect()).To(Equal("JSONWire"))
+		})
+
+		It("should return an empty string when the protocol is unknown", func() {
+			Expect(page.ProtocolDialect()).To(BeEmpty())
+		})
+	})
+
+	Describe("#EnableMetrics", func() {
+		It("should enable metrics for the session", func() {
+			page.EnableMetrics()
+			Expect(session.EnableMetricsCall.Called).To(BeTrue())
+		})
+	})
+
+	Describe("#Metrics", func() {
+		It("should return the metrics recorded by the session", func() {
+			metrics := map[string]api.EndpointMetric{"GET url": {Calls: 1, Duration: time.Second}}
+			session.MetricsCall.ReturnMetrics = metrics
+			Expect(page.Metrics()).To(Equal(metrics))
+		})
+	})
+
+	Describe("#SetTraceLogger", func() {
+		It("should provide the session with the trace logger", func() {
+			logger := &bytes.Buffer{}
+			page.SetTraceLogger(logger)
+			Expect(session.SetTraceLoggerCall.Logger).To(BeIdenticalTo(logger))
+		})
+	})
+
+	Describe("#Retry", func() {
+		var attempts int
+
+		BeforeEach(func() {
+			attempts = 0
+			UseFakeClock(page)
+		})
+
+		It("should return nil once an attempt succeeds", func() {
+			Expect(page.Retry(3, func() error {
+				attempts++
+				if attempts < 2 {
+					return errors.New("some error")
+				}
+				return nil
+			})).To(Succeed())
+			Expect(attempts).To(Equal(2))
+		})
+
+		It("should return the error from the last attempt when every attempt fails", func() {
+			err := page.Retry(3, func() error {
+				attempts++
+				return fmt.Errorf("error %d", attempts)
+			})
+			Expect(err).To(MatchError("error 3"))
+			Expect(attempts).To(Equal(3))
+		})
+
+		It("should log each failed attempt to the trace logger", func() {
+			logger := &bytes.Buffer{}
+			page.SetTraceLogger(logger)
+			page.Retry(2, func() error {
+				attempts++
+				return fmt.Errorf("error %d", attempts)
+			})
+			Expect(logger.String()).To(Equal("attempt 1 of 2 failed: error 1\nattempt 2 of 2 failed: error 2\n"))
+		})
+
+		Context("when the number of attempts is less than one", func() {
+			It("should return an error without calling the function", func() {
+				err := page.Retry(0, func() error {
+					attempts++
+					return nil
+				})
+				Expect(err).To(MatchError("invalid number of attempts: 0"))
+				Expect(attempts).To(BeZero())
+			})
+		})
+	})
+
 	Describe("#Destroy", func() {
 		It("should successfully delete the session", func() {
 			Expect(page.Destroy()).To(Succeed())
@@ -168,6 +326,100 @@ var _ = Describe("Page", func() {
 				Expect(page.Navigate("http://example.com")).To(MatchError("failed to navigate: some error"))
 			})
 		})
+
+		Context("when a base URL is set", func() {
+			BeforeEach(func() {
+				page.SetBaseURL("http://example.com/app/")
+			})
+
+			It("should resolve relative URLs against the base URL", func() {
+				Expect(page.Navigate("login")).To(Succeed())
+				Expect(session.SetURLCall.URL).To(Equal("http://example.com/app/login"))
+				Expect(page.Navigate("/login?next=home")).To(Succeed())
+				Expect(session.SetURLCall.URL).To(Equal("http://example.com/login?next=home"))
+			})
+
+			It("should not modify absolute URLs", func() {
+				Expect(page.Navigate("https://other.example.com/path")).To(Succeed())
+				Expect(session.SetURLCall.URL).To(Equal("https://other.example.com/path"))
+				Expect(page.Navigate("about:blank")).To(Succeed())
+				Expect(session.SetURLCall.URL).To(Equal("about:blank"))
+			})
+
+			Context("when the base URL is invalid", func() {
+				It("should return an error", func() {
+					page.SetBaseURL("http://example.com/%zz")
+					err := page.Navigate("login")
+					Expect(err).To(MatchError(ContainSubstring("failed to navigate: invalid base URL: ")))
+					Expect(session.SetURLCall.URL).To(BeEmpty())
+				})
+			})
+
+			Context("when the URL is invalid", func() {
+				It("should return an error", func() {
+					err := page.Navigate("%zz")
+					Expect(err).To(MatchError(ContainSubstring("failed to navigate: invalid URL: ")))
+				})
+			})
+		})
+	})
+
+	Describe("#NavigateWithRetry", func() {
+		var (
+			navigations *navigationSession
+			retryPage   *Page
+		)
+
+		BeforeEach(func() {
+			navigations = &navigationSession{Session: session}
+			retryPage = NewTestPage(navigations)
+			UseFakeClock(retryPage)
+		})
+
+		It("should retry navigation after network errors until it succeeds", func() {
+			navigations.errs = []error{
+				&api.ResponseError{Code: "unknown error", Message: "unknown error: net::ERR_CONNECTION_RESET"},
+				fmt.Errorf("request failed: %w", &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}),
+				fmt.Errorf("request failed: %w", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}),
+			}
+			Expect(retryPage.NavigateWithRetry("http://example.com", 4, time.Second)).To(Succeed())
+			Expect(navigations.calls).To(Equal(4))
+			Expect(session.SetURLCall.URL).To(Equal("http://example.com"))
+		})
+
+		It("should return the last error when every attempt fails", func() {
+			navigations.errs = []error{
+				&api.ResponseError{Code: "unknown error", Message: "unknown error: net::ERR_CONNECTION_REFUSED"},
+				&api.ResponseError{Code: "unknown error", Message: "unknown error: net::ERR_CONNECTION_RESET"},
+			}
+			err := retryPage.NavigateWithRetry("http://example.com", 2, time.Second)
+			Expect(err).To(MatchError("failed to navigate: request unsuccessful: unknown error: net::ERR_CONNECTION_RESET"))
+			Expect(navigations.calls).To(Equal(2))
+		})
+
+		Context("when navigation fails with a non-network error", func() {
+			It("should return the error without retrying", func() {
+				navigations.errs = []error{errors.New("invalid argument")}
+				err := retryPage.NavigateWithRetry("http://example.com", 3, time.Second)
+				Expect(err).To(MatchError("failed to navigate: invalid argument"))
+				Expect(navigations.calls).To(Equal(1))
+			})
+
+			It("should not retry transport failures other than connection resets and refusals", func() {
+				navigations.errs = []error{errors.New("request failed: net/http: TLS handshake timeout")}
+				err := retryPage.NavigateWithRetry("http://example.com", 3, time.Second)
+				Expect(err).To(MatchError("failed to navigate: request failed: net/http: TLS handshake timeout"))
+				Expect(navigations.calls).To(Equal(1))
+			})
+		})
+
+		Context("when the number of attempts is less than one", func() {
+			It("should return an error without navigating", func() {
+				err := retryPage.NavigateWithRetry("http://example.com", 0, time.Second)
+				Expect(err).To(MatchError("failed to navigate: invalid number of attempts: 0"))
+				Expect(navigations.calls).To(BeZero())
+			})
+		})
 	})
 
 	Describe("#GetCookies", func() {
@@ -181,6 +433,7 @@ var _ = Describe("Page", func() {
 					Secure:   true,
 					HTTPOnly: true,
 					Expiry:   100,
+					SameSite: "Lax",
 				},
 				{
 					Name:     "some other cookie",
@@ -201,6 +454,7 @@ var _ = Describe("Page", func() {
 					Secure:   true,
 					HttpOnly: true,
 					Expires:  time.Unix(100, 0),
+					SameSite: http.SameSiteLaxMode,
 				},
 				{
 					Name:     "some other cookie",
@@ -233,6 +487,7 @@ var _ = Describe("Page", func() {
 				Secure:   true,
 				HttpOnly: true,
 				Expires:  time.Unix(100, 0),
+				SameSite: http.SameSiteStrictMode,
 			}
 			Expect(page.SetCookie(cookie)).To(Succeed())
 			Expect(session.SetCookieCall.Cookie).To(Equal(&api.Cookie{
@@ -243,6 +498,7 @@ var _ = Describe("Page", func() {
 				Secure:   true,
 				HTTPOnly: true,
 				Expiry:   100,
+				SameSite: "Strict",
 			}))
 		})
 
@@ -312,6 +568,40 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#WaitForURL", func() {
+		BeforeEach(func() {
+			UseFakeClock(page)
+		})
+
+		It("should successfully return when the URL matches the pattern", func() {
+			session.GetURLCall.ReturnURL = "http://example.com/dashboard?tab=1"
+			Expect(page.WaitForURL(`/dashboard\b`, time.Second)).To(Succeed())
+		})
+
+		Context("when the URL does not match before the timeout", func() {
+			It("should return an error including the last URL", func() {
+				session.GetURLCall.ReturnURL = "http://example.com/login"
+				err := page.WaitForURL("/dashboard", 20*time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for URL to match '/dashboard' (last: 'http://example.com/login')"))
+			})
+		})
+
+		Context("when the URL cannot be retrieved before the timeout", func() {
+			It("should return an error", func() {
+				session.GetURLCall.Err = errors.New("some error")
+				err := page.WaitForURL("/dashboard", 20*time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for URL to match '/dashboard' (last: '')"))
+			})
+		})
+
+		Context("when the pattern is invalid", func() {
+			It("should return an error", func() {
+				err := page.WaitForURL("(", time.Second)
+				Expect(err).To(MatchError(ContainSubstring("invalid URL pattern: ")))
+			})
+		})
+	})
+
 	Describe("#Size", func() {
 		var (
 			bus    *mocks.Bus
@@ -345,6 +635,318 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#WindowRect", func() {
+		Context("when the WebDriver uses the W3C protocol", func() {
+			BeforeEach(func() {
+				session.ProtocolCall.ReturnProtocol = "w3c"
+			})
+
+			It("should return the window rect from a single request", func() {
+				session.GetWindowRectCall.ReturnX = 10
+				session.GetWindowRectCall.ReturnY = 20
+				session.GetWindowRectCall.ReturnWidth = 640
+				session.GetWindowRectCall.ReturnHeight = 480
+				x, y, width, height, err := page.WindowRect()
+				Expect(err).NotTo(HaveOccurred())
+				Expect([]int{x, y, width, height}).To(Equal([]int{10, 20, 640, 480}))
+			})
+
+			Context("when the session fails to retrieve the window rect", func() {
+				It("should return an error", func() {
+					session.GetWindowRectCall.Err = errors.New("some error")
+					_, _, _, _, err := page.WindowRect()
+					Expect(err).To(MatchError("failed to retrieve window rect: some error"))
+				})
+			})
+		})
+
+		Context("when the WebDriver uses the JSON Wire Protocol", func() {
+			var bus *mocks.Bus
+
+			BeforeEach(func() {
+				session.ProtocolCall.ReturnProtocol = "jsonwire"
+				bus = &mocks.Bus{}
+				session.GetWindowCall.ReturnWindow = &api.Window{ID: "some-id", Session: &api.Session{Bus: bus}}
+			})
+
+			It("should return the window position and size", func() {
+				bus.SendCall.Result = `{"x": 10, "y": 20, "width": 640, "height": 480}`
+				x, y, width, height, err := page.WindowRect()
+				Expect(err).NotTo(HaveOccurred())
+				Expect([]int{x, y, width, height}).To(Equal([]int{10, 20, 640, 480}))
+				Expect(bus.SendCall.Endpoint).To(Equal("window/some-id/size"))
+			})
+
+			Context("when the session fails to retrieve a window", func() {
+				It("should return an error", func() {
+					session.GetWindowCall.Err = errors.New("some error")
+					_, _, _, _, err := page.WindowRect()
+					Expect(err).To(MatchError("failed to retrieve window: some error"))
+				})
+			})
+
+			Context("when the window fails to retrieve its position or size", func() {
+				It("should return an error", func() {
+					bus.SendCall.Err = errors.New("some error")
+					_, _, _, _, err := page.WindowRect()
+					Expect(err).To(MatchError("failed to retrieve window rect: some error"))
+				})
+			})
+		})
+	})
+
+	Describe("#SetWindowRect", func() {
+		Context("when the WebDriver uses the W3C protocol", func() {
+			BeforeEach(func() {
+				session.ProtocolCall.ReturnProtocol = "w3c"
+			})
+
+			It("should set the window rect with a single request", func() {
+				Expect(page.SetWindowRect(10, 20, 640, 480)).To(Succeed())
+				Expect(session.SetWindowRectCall.X).To(Equal(10))
+				Expect(session.SetWindowRectCall.Y).To(Equal(20))
+				Expect(session.SetWindowRectCall.Width).To(Equal(640))
+				Expect(session.SetWindowRectCall.Height).To(Equal(480))
+			})
+
+			Context("when the session fails to set the window rect", func() {
+				It("should return an error", func() {
+					session.SetWindowRectCall.Err = errors.New("some error")
+					Expect(page.SetWindowRect(10, 20, 640, 480)).To(MatchError("failed to set window rect: some error"))
+				})
+			})
+		})
+
+		Context("when the WebDriver uses the JSON Wire Protocol", func() {
+			var bus *mocks.Bus
+
+			BeforeEach(func() {
+				session.ProtocolCall.ReturnProtocol = "jsonwire"
+				bus = &mocks.Bus{}
+				session.GetWindowCall.ReturnWindow = &api.Window{ID: "some-id", Session: &api.Session{Bus: bus}}
+			})
+
+			It("should move and then resize the window", func() {
+				Expect(page.SetWindowRect(10, 20, 640, 480)).To(Succeed())
+				Expect(bus.SendCall.Endpoint).To(Equal("window/some-id/size"))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"width": 640, "height": 480}`))
+				Expect(session.SetWindowRectCall.Width).To(BeZero())
+			})
+
+			Context("when the session fails to retrieve a window", func() {
+				It("should return an error", func() {
+					session.GetWindowCall.Err = errors.New("some error")
+					Expect(page.SetWindowRect(10, 20, 640, 480)).To(MatchError("failed to retrieve window: some error"))
+				})
+			})
+
+			Context("when the window fails to move or resize", func() {
+				It("should return an error", func() {
+					bus.SendCall.Err = errors.New("some error")
+					Expect(page.SetWindowRect(10, 20, 640, 480)).To(MatchError("failed to set window rect: some error"))
+				})
+			})
+		})
+	})
+
+	Describe("#Position", func() {
+		var (
+			bus    *mocks.Bus
+			window *api.Window
+		)
+
+		BeforeEach(func() {
+			bus = &mocks.Bus{}
+			window = &api.Window{ID: "some-id", Session: &api.Session{Bus: bus}}
+			session.GetWindowCall.ReturnWindow = window
+		})
+
+		It("should return the position of the current window", func() {
+			bus.SendCall.Result = `{"x": 100, "y": 200}`
+			x, y, err := page.Position()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(x).To(Equal(100))
+			Expect(y).To(Equal(200))
+			Expect(bus.SendCall.Endpoint).To(Equal("window/some-id/position"))
+		})
+
+		Context("when the session fails to retrieve a window", func() {
+			It("should return an error", func() {
+				session.GetWindowCall.Err = errors.New("some error")
+				_, _, err := page.Position()
+				Expect(err).To(MatchError("failed to retrieve window: some error"))
+			})
+		})
+
+		Context("when the window fails to retrieve its position", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				_, _, err := page.Position()
+				Expect(err).To(MatchError("failed to retrieve window position: some error"))
+			})
+		})
+	})
+
+	Describe("#Move", func() {
+		var (
+			bus    *mocks.Bus
+			window *api.Window
+		)
+
+		BeforeEach(func() {
+			bus = &mocks.Bus{}
+			window = &api.Window{ID: "some-id", Session: &api.Session{Bus: bus}}
+			session.GetWindowCall.ReturnWindow = window
+		})
+
+		It("should move the current window to the provided position", func() {
+			Expect(page.Move(100, 200)).To(Succeed())
+			Expect(bus.SendCall.Endpoint).To(Equal("window/some-id/position"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"x": 100, "y": 200}`))
+		})
+
+		Context("when the session fails to retrieve a window", func() {
+			It("should return an error", func() {
+				session.GetWindowCall.Err = errors.New("some error")
+				Expect(page.Move(100, 200)).To(MatchError("failed to retrieve window: some error"))
+			})
+		})
+
+		Context("when the window fails to move", func() {
+			It("should return an error", func() {
+				bus.SendCall.Err = errors.New("some error")
+				Expect(page.Move(100, 200)).To(MatchError("failed to move window: some error"))
+			})
+		})
+	})
+
+	Describe("#Fullscreen", func() {
+		It("should successfully instruct the session to make the window fullscreen", func() {
+			Expect(page.Fullscreen()).To(Succeed())
+			Expect(session.FullscreenCall.Called).To(BeTrue())
+		})
+
+		Context("when the WebDriver does not support fullscreen", func() {
+			It("should return an unsupported error", func() {
+				session.FullscreenCall.Err = errors.New("request unsuccessful: unknown command: session/some-id/window/fullscreen")
+				Expect(page.Fullscreen()).To(MatchError("failed to enter fullscreen: not supported by this WebDriver"))
+			})
+		})
+
+		Context("when making the window fullscreen fails", func() {
+			It("should return an error", func() {
+				session.FullscreenCall.Err = errors.New("some error")
+				Expect(page.Fullscreen()).To(MatchError("failed to enter fullscreen: some error"))
+			})
+		})
+	})
+
+	Describe("#SetPermission", func() {
+		It("should successfully send the permission state to the session", func() {
+			Expect(page.SetPermission("geolocation", "granted")).To(Succeed())
+			Expect(session.SetPermissionCall.Name).To(Equal("geolocation"))
+			Expect(session.SetPermissionCall.State).To(Equal("granted"))
+		})
+
+		Context("when the state is invalid", func() {
+			It("should return an error without contacting the session", func() {
+				err := page.SetPermission("geolocation", "allowed")
+				Expect(err).To(MatchError(`failed to set permission 'geolocation': invalid state 'allowed', expected "granted", "denied", or "prompt"`))
+				Expect(session.SetPermissionCall.Name).To(BeEmpty())
+			})
+		})
+
+		Context("when the WebDriver does not support permissions", func() {
+			It("should return an error indicating that it is not supported", func() {
+				session.SetPermissionCall.Err = errors.New("request unsuccessful: unknown command: permissions")
+				Expect(page.SetPermission("geolocation", "denied")).To(MatchError("failed to set permission 'geolocation': not supported by this WebDriver"))
+			})
+		})
+
+		Context("when the session fails to set the permission", func() {
+			It("should return an error", func() {
+				session.SetPermissionCall.Err = errors.New("some error")
+				Expect(page.SetPermission("geolocation", "prompt")).To(MatchError("failed to set permission 'geolocation': some error"))
+			})
+		})
+	})
+
+	Describe("#SetNetworkConditions", func() {
+		It("should successfully send the network conditions to the session", func() {
+			Expect(page.SetNetworkConditions(true, 100, 2000, 3000)).To(Succeed())
+			Expect(session.SetNetworkConditionsCall.Offline).To(BeTrue())
+			Expect(session.SetNetworkConditionsCall.Latency).To(Equal(100))
+			Expect(session.SetNetworkConditionsCall.DownloadThroughput).To(Equal(2000))
+			Expect(session.SetNetworkConditionsCall.UploadThroughput).To(Equal(3000))
+		})
+
+		Context("when the WebDriver does not support network conditions", func() {
+			It("should return an error indicating that it is not supported", func() {
+				session.SetNetworkConditionsCall.Err = errors.New("request unsuccessful: unknown command: chromium/network_conditions")
+				Expect(page.SetNetworkConditions(false, 0, 0, 0)).To(MatchError("failed to set network conditions: not supported by this driver"))
+			})
+		})
+
+		Context("when the session fails to set the network conditions", func() {
+			It("should return an error", func() {
+				session.SetNetworkConditionsCall.Err = errors.New("some error")
+				Expect(page.SetNetworkConditions(false, 0, 0, 0)).To(MatchError("failed to set network conditions: some error"))
+			})
+		})
+	})
+
+	Describe("#ClearCache", func() {
+		It("should successfully clear the browser cache using the DevTools Protocol", func() {
+			Expect(page.ClearCache()).To(Succeed())
+			Expect(session.ExecuteCDPCall.Command).To(Equal("Network.clearBrowserCache"))
+		})
+
+		Context("when the WebDriver does not support DevTools Protocol commands", func() {
+			It("should return an error indicating that it is not supported", func() {
+				session.ExecuteCDPCall.Err = errors.New("request unsuccessful: unknown command: goog/cdp/execute")
+				Expect(page.ClearCache()).To(MatchError("failed to clear cache: not supported by this WebDriver"))
+			})
+		})
+
+		Context("when the session fails to clear the cache", func() {
+			It("should return an error", func() {
+				session.ExecuteCDPCall.Err = errors.New("some error")
+				Expect(page.ClearCache()).To(MatchError("failed to clear cache: some error"))
+			})
+		})
+	})
+
+	Describe("#EmulateColorScheme", func() {
+		It("should successfully emulate the color scheme using the DevTools Protocol", func() {
+			Expect(page.EmulateColorScheme("dark")).To(Succeed())
+			Expect(session.ExecuteCDPCall.Command).To(Equal("Emulation.setEmulatedMedia"))
+			Expect(session.ExecuteCDPCall.Params).To(Equal(map[string]interface{}{
+				"features": []map[string]string{{"name": "prefers-color-scheme", "value": "dark"}},
+			}))
+		})
+
+		Context("when the scheme is invalid", func() {
+			It("should return an error without calling the session", func() {
+				Expect(page.EmulateColorScheme("blue")).To(MatchError(`failed to emulate color scheme: invalid scheme 'blue', expected "dark" or "light"`))
+				Expect(session.ExecuteCDPCall.Command).To(BeEmpty())
+			})
+		})
+
+		Context("when the WebDriver does not support DevTools Protocol commands", func() {
+			It("should return an error indicating that it is not supported", func() {
+				session.ExecuteCDPCall.Err = errors.New("request unsuccessful: unknown command: goog/cdp/execute")
+				Expect(page.EmulateColorScheme("light")).To(MatchError("failed to emulate color scheme: not supported by this WebDriver"))
+			})
+		})
+
+		Context("when the session fails to emulate the color scheme", func() {
+			It("should return an error", func() {
+				session.ExecuteCDPCall.Err = errors.New("some error")
+				Expect(page.EmulateColorScheme("light")).To(MatchError("failed to emulate color scheme: some error"))
+			})
+		})
+	})
+
 	Describe("#Screenshot", func() {
 		It("should successfully saves the screenshot", func() {
 			session.GetScreenshotCall.ReturnImage = []byte("some-image")
@@ -371,6 +973,39 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#PrintPDF", func() {
+		It("should successfully save the PDF", func() {
+			session.PrintPDFCall.ReturnPDF = []byte("some-pdf")
+			filename, _ := filepath.Abs(".test.print.pdf")
+			Expect(page.PrintPDF(".test.print.pdf", PrintOptions{})).To(Succeed())
+			defer os.Remove(filename)
+			result, _ := ioutil.ReadFile(filename)
+			Expect(string(result)).To(Equal("some-pdf"))
+		})
+
+		It("should pass the print options to the session", func() {
+			filename, _ := filepath.Abs(".test.print.pdf")
+			defer os.Remove(filename)
+			Expect(page.PrintPDF(".test.print.pdf", PrintOptions{Landscape: true, Scale: 0.5, Background: true})).To(Succeed())
+			Expect(session.PrintPDFCall.Options).To(Equal(api.PrintOptions{Orientation: "landscape", Scale: 0.5, Background: true}))
+		})
+
+		Context("when a new PDF file cannot be saved", func() {
+			It("should return an error", func() {
+				err := page.PrintPDF("", PrintOptions{})
+				Expect(err.Error()).To(ContainSubstring("failed to print page to PDF: open"))
+			})
+		})
+
+		Context("when the session fails to print the page", func() {
+			It("should return an error", func() {
+				session.PrintPDFCall.Err = errors.New("some error")
+				err := page.PrintPDF(".test.print.pdf", PrintOptions{})
+				Expect(err).To(MatchError("failed to print page to PDF: some error"))
+			})
+		})
+	})
+
 	Describe("#Title", func() {
 		It("should successfully return the title of the current page", func() {
 			session.GetTitleCall.ReturnTitle = "Some Title"
@@ -428,11 +1063,254 @@ var _ = Describe("Page", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		Context("when running the script fails", func() {
-			It("should return the session error", func() {
-				session.ExecuteCall.Err = errors.New("some error")
-				err = page.RunScript("", map[string]interface{}{}, &result)
-				Expect(err).To(MatchError("failed to run script: some error"))
+		Context("when running the script fails", func() {
+			It("should return the session error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				err = page.RunScript("", map[string]interface{}{}, &result)
+				Expect(err).To(MatchError("failed to run script: some error"))
+			})
+		})
+	})
+
+	Describe("#RunScript with element results", func() {
+		var elements []*api.Element
+
+		BeforeEach(func() {
+			elements = []*api.Element{{ID: "some-id"}, {ID: "other-id"}}
+			session.ExecuteElementsCall.ReturnElements = elements
+		})
+
+		Context("when the result is a *Selection", func() {
+			It("should provide the session with the argument-provided javascript function and arguments", func() {
+				session.ExecuteElementsCall.ReturnElements = elements[:1]
+				var selection *Selection
+				Expect(page.RunScript("some javascript code", map[string]interface{}{"argument": "value"}, &selection)).To(Succeed())
+				Expect(session.ExecuteElementsCall.Body).To(Equal("return (function(argument) { some javascript code; }).apply(this, arguments);"))
+				Expect(session.ExecuteElementsCall.Arguments).To(Equal([]interface{}{"value"}))
+			})
+
+			It("should successfully return a selection of the returned element", func() {
+				session.ExecuteElementsCall.ReturnElements = elements[:1]
+				var selection *Selection
+				Expect(page.RunScript("", nil, &selection)).To(Succeed())
+				Expect(selection.String()).To(Equal("selection 'Script Result [single]'"))
+				Expect(selection.Elements()).To(Equal(elements[:1]))
+			})
+
+			Context("when the script does not return exactly one element", func() {
+				It("should return an error", func() {
+					var selection *Selection
+					err := page.RunScript("", nil, &selection)
+					Expect(err).To(MatchError("failed to run script: expected one element but script returned 2"))
+				})
+			})
+		})
+
+		Context("when the result is a []*Selection", func() {
+			It("should successfully return a selection of each returned element in order", func() {
+				var selections []*Selection
+				Expect(page.RunScript("", nil, &selections)).To(Succeed())
+				Expect(selections).To(HaveLen(2))
+				Expect(selections[0].String()).To(Equal("selection 'Script Result [0]'"))
+				Expect(selections[0].Elements()).To(Equal(elements[:1]))
+				Expect(selections[1].String()).To(Equal("selection 'Script Result [1]'"))
+				Expect(selections[1].Elements()).To(Equal(elements[1:]))
+			})
+		})
+
+		Context("when running the script fails", func() {
+			It("should return an error", func() {
+				session.ExecuteElementsCall.Err = errors.New("some error")
+				var selections []*Selection
+				Expect(page.RunScript("", nil, &selections)).To(MatchError("failed to run script: some error"))
+			})
+		})
+
+		Context("when the result is a [][]*Selection", func() {
+			BeforeEach(func() {
+				session.ExecuteNestedElementsCall.ReturnResult = []interface{}{
+					[]interface{}{elements[0], elements[1]},
+					[]interface{}{},
+					nil,
+				}
+			})
+
+			It("should provide the session with the argument-provided javascript function and arguments", func() {
+				var rows [][]*Selection
+				Expect(page.RunScript("some javascript code", map[string]interface{}{"argument": "value"}, &rows)).To(Succeed())
+				Expect(session.ExecuteNestedElementsCall.Body).To(Equal("return (function(argument) { some javascript code; }).apply(this, arguments);"))
+				Expect(session.ExecuteNestedElementsCall.Arguments).To(Equal([]interface{}{"value"}))
+			})
+
+			It("should successfully return selections of the returned elements grouped by array", func() {
+				var rows [][]*Selection
+				Expect(page.RunScript("", nil, &rows)).To(Succeed())
+				Expect(rows).To(HaveLen(3))
+				Expect(rows[0]).To(HaveLen(2))
+				Expect(rows[0][0].String()).To(Equal("selection 'Script Result [0]'"))
+				Expect(rows[0][0].Elements()).To(Equal(elements[:1]))
+				Expect(rows[0][1].String()).To(Equal("selection 'Script Result [1]'"))
+				Expect(rows[0][1].Elements()).To(Equal(elements[1:]))
+				Expect(rows[1]).To(BeEmpty())
+				Expect(rows[2]).To(BeEmpty())
+			})
+
+			It("should support arrays nested at any depth", func() {
+				session.ExecuteNestedElementsCall.ReturnResult = []interface{}{
+					[]interface{}{[]interface{}{elements[0]}, []interface{}{elements[1]}},
+				}
+				var tables [][][]*Selection
+				Expect(page.RunScript("", nil, &tables)).To(Succeed())
+				Expect(tables[0][1][0].String()).To(Equal("selection 'Script Result [1]'"))
+				Expect(tables[0][1][0].Elements()).To(Equal(elements[1:]))
+			})
+
+			Context("when the script returns elements at a different depth", func() {
+				It("should return an error", func() {
+					session.ExecuteNestedElementsCall.ReturnResult = []interface{}{elements[0]}
+					var rows [][]*Selection
+					Expect(page.RunScript("", nil, &rows)).To(MatchError("failed to run script: expected an array but script returned an element"))
+					session.ExecuteNestedElementsCall.ReturnResult = []interface{}{[]interface{}{[]interface{}{}}}
+					Expect(page.RunScript("", nil, &rows)).To(MatchError("failed to run script: expected an element but script returned an array or null"))
+				})
+			})
+
+			Context("when running the script fails", func() {
+				It("should return an error", func() {
+					session.ExecuteNestedElementsCall.Err = errors.New("some error")
+					var rows [][]*Selection
+					Expect(page.RunScript("", nil, &rows)).To(MatchError("failed to run script: some error"))
+				})
+			})
+		})
+	})
+
+	Describe("#WaitForScript", func() {
+		BeforeEach(func() {
+			UseFakeClock(page)
+		})
+
+		It("should successfully return when the predicate returns true", func() {
+			session.ExecuteCall.Result = "true"
+			Expect(page.WaitForScript("return window.appReady;", time.Second)).To(Succeed())
+			Expect(session.ExecuteCall.Body).To(ContainSubstring("return window.appReady;"))
+		})
+
+		Context("when the predicate does not return true before the timeout", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Result = "false"
+				Expect(page.WaitForScript("return window.appReady;", 20*time.Millisecond)).To(MatchError("timed out after 20ms waiting for script condition"))
+			})
+		})
+
+		Context("when the predicate fails to run", func() {
+			It("should return an error immediately", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(page.WaitForScript("return window.appReady;", time.Hour)).To(MatchError("failed to run script: some error"))
+			})
+		})
+	})
+
+	Describe("#WaitForAjax", func() {
+		BeforeEach(func() {
+			UseFakeClock(page)
+		})
+
+		It("should successfully return when there are no active jQuery requests", func() {
+			session.ExecuteCall.Result = "true"
+			Expect(page.WaitForAjax(time.Second)).To(Succeed())
+			Expect(session.ExecuteCall.Body).To(ContainSubstring(`typeof window.jQuery === "undefined" || window.jQuery.active === 0`))
+		})
+
+		Context("when jQuery requests are still active after the timeout", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Result = "false"
+				Expect(page.WaitForAjax(20 * time.Millisecond)).To(MatchError("timed out after 20ms waiting for AJAX to complete"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error immediately", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(page.WaitForAjax(time.Hour)).To(MatchError("failed to run script: some error"))
+			})
+		})
+	})
+
+	Describe("#WaitForDownload", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "agouti-download")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should return the path of a completed download matching the glob", func() {
+			go func() {
+				time.Sleep(20 * time.Millisecond)
+				ioutil.WriteFile(filepath.Join(dir, "report.csv"), nil, 0644)
+			}()
+			Expect(page.WaitForDownload(dir, "*.csv", time.Second)).To(Equal(filepath.Join(dir, "report.csv")))
+		})
+
+		It("should return a matching file that is modified during the wait", func() {
+			download := filepath.Join(dir, "report.csv")
+			Expect(ioutil.WriteFile(download, nil, 0644)).To(Succeed())
+			go func() {
+				time.Sleep(20 * time.Millisecond)
+				later := time.Now().Add(time.Hour)
+				os.Chtimes(download, later, later)
+			}()
+			Expect(page.WaitForDownload(dir, "*.csv", time.Second)).To(Equal(download))
+		})
+
+		It("should wait for a partial download to finish", func() {
+			partial := filepath.Join(dir, "report.csv.crdownload")
+			Expect(ioutil.WriteFile(partial, nil, 0644)).To(Succeed())
+			go func() {
+				time.Sleep(20 * time.Millisecond)
+				os.Rename(partial, filepath.Join(dir, "report.csv"))
+			}()
+			Expect(page.WaitForDownload(dir, "report*", time.Second)).To(Equal(filepath.Join(dir, "report.csv")))
+		})
+
+		It("should ignore a file while its Firefox partial download is in progress", func() {
+			Expect(ioutil.WriteFile(filepath.Join(dir, "report.csv.part"), nil, 0644)).To(Succeed())
+			go func() {
+				time.Sleep(20 * time.Millisecond)
+				ioutil.WriteFile(filepath.Join(dir, "report.csv"), nil, 0644)
+			}()
+			_, err := page.WaitForDownload(dir, "report*", 100*time.Millisecond)
+			Expect(err).To(MatchError("timed out after 100ms waiting for download matching 'report*' in '" + dir + "'"))
+		})
+
+		Context("when no matching file finishes downloading before the timeout", func() {
+			It("should return an error", func() {
+				UseFakeClock(page)
+				Expect(ioutil.WriteFile(filepath.Join(dir, "report.csv.crdownload"), nil, 0644)).To(Succeed())
+				_, err := page.WaitForDownload(dir, "*", 20*time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for download matching '*' in '" + dir + "'"))
+			})
+		})
+
+		Context("when a matching file already exists and is not modified", func() {
+			It("should return an error", func() {
+				UseFakeClock(page)
+				Expect(ioutil.WriteFile(filepath.Join(dir, "report.csv"), nil, 0644)).To(Succeed())
+				_, err := page.WaitForDownload(dir, "*.csv", time.Second)
+				Expect(err).To(MatchError("timed out after 1s waiting for download matching '*.csv' in '" + dir + "'"))
+			})
+		})
+
+		Context("when the glob is malformed", func() {
+			It("should return an error", func() {
+				_, err := page.WaitForDownload(dir, "[", time.Hour)
+				Expect(err).To(MatchError("failed to wait for download matching '[': syntax error in pattern"))
 			})
 		})
 	})
@@ -494,6 +1372,220 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#IsAlertPresent", func() {
+		It("should return true when an alert is open", func() {
+			session.GetAlertTextCall.ReturnText = "some text"
+			Expect(page.IsAlertPresent()).To(BeTrue())
+		})
+
+		Context("when the session indicates that there is no such alert", func() {
+			It("should return false without an error", func() {
+				session.GetAlertTextCall.Err = errors.New("request unsuccessful: no such alert")
+				Expect(page.IsAlertPresent()).To(BeFalse())
+			})
+		})
+
+		Context("when a legacy driver indicates that no alert is open", func() {
+			It("should return false without an error", func() {
+				session.GetAlertTextCall.Err = errors.New("request unsuccessful: no alert open")
+				Expect(page.IsAlertPresent()).To(BeFalse())
+			})
+		})
+
+		Context("when the session fails to retrieve the alert text for another reason", func() {
+			It("should return an error", func() {
+				session.GetAlertTextCall.Err = errors.New("some error")
+				_, err := page.IsAlertPresent()
+				Expect(err).To(MatchError("failed to determine whether an alert is present: some error"))
+			})
+		})
+	})
+
+	Describe("#AutoAcceptAlerts", func() {
+		Context("when enabled", func() {
+			It("should successfully replace the popup functions on the current document", func() {
+				Expect(page.AutoAcceptAlerts(true)).To(Succeed())
+				Expect(session.ExecuteCall.Body).To(ContainSubstring("window.confirm = function() { return true; };"))
+			})
+
+			It("should replace the popup functions again after each navigation", func() {
+				Expect(page.AutoAcceptAlerts(true)).To(Succeed())
+				navigations := map[string]func() error{
+					"Navigate": func() error { return page.Navigate("http://example.com") },
+					"Forward":  page.Forward,
+					"Back":     page.Back,
+					"Refresh":  page.Refresh,
+				}
+				for name, navigate := range navigations {
+					session.ExecuteCall.Body = ""
+					Expect(navigate()).To(Succeed(), name)
+					Expect(session.ExecuteCall.Body).To(ContainSubstring("window.alert = function() {};"), name)
+				}
+			})
+
+			Context("when replacing the popup functions fails", func() {
+				It("should return an error", func() {
+					session.ExecuteCall.Err = errors.New("some error")
+					Expect(page.AutoAcceptAlerts(true)).To(MatchError("failed to install alert handlers: some error"))
+					Expect(page.Navigate("http://example.com")).To(MatchError("failed to install alert handlers: some error"))
+				})
+			})
+		})
+
+		Context("when disabled", func() {
+			It("should successfully restore the native popup functions", func() {
+				Expect(page.AutoAcceptAlerts(true)).To(Succeed())
+				Expect(page.AutoAcceptAlerts(false)).To(Succeed())
+				Expect(session.ExecuteCall.Body).To(ContainSubstring("window.confirm = dialogs.confirm;"))
+			})
+
+			It("should not replace the popup functions after navigating", func() {
+				Expect(page.AutoAcceptAlerts(false)).To(Succeed())
+				session.ExecuteCall.Body = ""
+				Expect(page.Navigate("http://example.com")).To(Succeed())
+				Expect(session.ExecuteCall.Body).To(BeEmpty())
+			})
+
+			Context("when restoring the popup functions fails", func() {
+				It("should return an error", func() {
+					session.ExecuteCall.Err = errors.New("some error")
+					Expect(page.AutoAcceptAlerts(false)).To(MatchError("failed to restore alert handlers: some error"))
+				})
+			})
+		})
+	})
+
+	Describe("#SendKeys", func() {
+		It("should successfully send the combined keys to the session", func() {
+			Expect(page.SendKeys(key.Control, "k")).To(Succeed())
+			Expect(session.KeysCall.Text).To(Equal("\uE009k"))
+		})
+
+		Context("when the session fails to send the keys", func() {
+			It("should return an error", func() {
+				session.KeysCall.Err = errors.New("some error")
+				Expect(page.SendKeys("/")).To(MatchError("failed to send keys: some error"))
+			})
+		})
+	})
+
+	Describe("#TabOrder", func() {
+		var (
+			nameElement, emailElement *mocks.Element
+			selections                []*Selection
+		)
+
+		BeforeEach(func() {
+			nameElement, emailElement = &mocks.Element{}, &mocks.Element{}
+			nameElement.IsEqualToCall.ReturnEquals = true
+			emailElement.IsEqualToCall.ReturnEquals = true
+			nameRepository, emailRepository := &mocks.ElementRepository{}, &mocks.ElementRepository{}
+			nameRepository.GetExactlyOneCall.ReturnElement = nameElement
+			emailRepository.GetExactlyOneCall.ReturnElement = emailElement
+			selections = []*Selection{
+				NewTestSelection(session, nameRepository, "#name"),
+				NewTestSelection(session, emailRepository, "#email"),
+			}
+			session.GetActiveElementCall.ReturnElement = &api.Element{ID: "active"}
+		})
+
+		It("should focus the body, press tab and compare each selection to the active element", func() {
+			Expect(page.TabOrder(selections)).To(BeTrue())
+			Expect(session.ExecuteCall.Body).To(ContainSubstring("document.body.focus();"))
+			Expect(session.KeysCall.Text).To(Equal(key.Tab))
+			Expect(nameElement.IsEqualToCall.Element).To(Equal(&api.Element{ID: "active"}))
+			Expect(emailElement.IsEqualToCall.Element).To(Equal(&api.Element{ID: "active"}))
+		})
+
+		Context("when a different element is focused", func() {
+			It("should return false with an error naming the step and the focused element", func() {
+				emailElement.IsEqualToCall.ReturnEquals = false
+				session.ExecuteCall.Result = `"<input id=\"other\">"`
+				inOrder, err := page.TabOrder(selections)
+				Expect(inOrder).To(BeFalse())
+				Expect(err).To(MatchError(`tab order mismatch at step 2: expected selection 'CSS: #email [single]' to be focused, but <input id="other"> is focused`))
+				Expect(session.ExecuteCall.Body).To(ContainSubstring("outerHTML"))
+				Expect(session.ExecuteCall.Arguments).To(HaveLen(1))
+			})
+
+			Context("when the focused element cannot be described", func() {
+				It("should name the focused element by its ID", func() {
+					emailElement.IsEqualToCall.ReturnEquals = false
+					_, err := page.TabOrder(selections)
+					Expect(err).To(MatchError("tab order mismatch at step 2: expected selection 'CSS: #email [single]' to be focused, but element 'active' is focused"))
+				})
+			})
+		})
+
+		Context("when a selection does not refer to exactly one element", func() {
+			It("should return an error", func() {
+				emailRepository := &mocks.ElementRepository{}
+				emailRepository.GetExactlyOneCall.Err = errors.New("some error")
+				selections[1] = NewTestSelection(session, emailRepository, "#email")
+				_, err := page.TabOrder(selections)
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #email [single]': some error"))
+			})
+		})
+
+		Context("when the selection cannot be compared to the active element", func() {
+			It("should return an error", func() {
+				nameElement.IsEqualToCall.Err = errors.New("some error")
+				_, err := page.TabOrder(selections)
+				Expect(err).To(MatchError("failed to compare selection 'CSS: #name [single]' to active element: some error"))
+			})
+		})
+
+		Context("when focusing the body fails", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err := page.TabOrder(selections)
+				Expect(err).To(MatchError("failed to focus page body: some error"))
+			})
+		})
+
+		Context("when pressing tab fails", func() {
+			It("should return an error", func() {
+				session.KeysCall.Err = errors.New("some error")
+				_, err := page.TabOrder(selections)
+				Expect(err).To(MatchError("failed to press tab: some error"))
+			})
+		})
+
+		Context("when the active element cannot be retrieved", func() {
+			It("should return an error", func() {
+				session.GetActiveElementCall.Err = errors.New("some error")
+				_, err := page.TabOrder(selections)
+				Expect(err).To(MatchError("failed to retrieve active element: some error"))
+			})
+		})
+
+		Context("when the session uses the W3C protocol", func() {
+			var bus *mocks.Bus
+
+			BeforeEach(func() {
+				session.ProtocolCall.ReturnProtocol = "w3c"
+				bus = &mocks.Bus{}
+				session.GetActiveElementCall.ReturnElement = &api.Element{ID: "active", Session: &api.Session{Bus: bus}}
+			})
+
+			It("should send tab to the active element instead of using the keys endpoint", func() {
+				Expect(page.TabOrder(selections)).To(BeTrue())
+				Expect(session.KeysCall.Text).To(BeEmpty())
+				Expect(bus.SendCall.Method).To(Equal("POST"))
+				Expect(bus.SendCall.Endpoint).To(Equal("element/active/value"))
+				Expect(string(bus.SendCall.BodyJSON)).To(ContainSubstring(key.Tab))
+			})
+
+			Context("when sending tab fails", func() {
+				It("should return an error", func() {
+					bus.SendCall.Err = errors.New("some error")
+					_, err := page.TabOrder(selections)
+					Expect(err).To(MatchError("failed to press tab: some error"))
+				})
+			})
+		})
+	})
+
 	Describe("#Forward", func() {
 		It("should successfully instruct the session to move forward in history", func() {
 			Expect(page.Forward()).To(Succeed())
@@ -536,6 +1628,42 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#RefreshAndWait", func() {
+		BeforeEach(func() {
+			UseFakeClock(page)
+		})
+
+		It("should successfully refresh and wait for the reloaded document to load", func() {
+			session.ExecuteCall.Result = "true"
+			Expect(page.RefreshAndWait(time.Second)).To(Succeed())
+			Expect(session.RefreshCall.Called).To(BeTrue())
+			Expect(session.ExecuteCall.Body).To(ContainSubstring("!window.__agoutiNavigationMarker"))
+			Expect(session.ExecuteCall.Body).To(ContainSubstring(`document.readyState === "complete"`))
+		})
+
+		Context("when marking the current document fails", func() {
+			It("should return an error without refreshing", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(page.RefreshAndWait(time.Second)).To(MatchError("failed to mark current document: some error"))
+				Expect(session.RefreshCall.Called).To(BeFalse())
+			})
+		})
+
+		Context("when refreshing the page fails", func() {
+			It("should return an error", func() {
+				session.RefreshCall.Err = errors.New("some error")
+				Expect(page.RefreshAndWait(time.Second)).To(MatchError("failed to refresh page: some error"))
+			})
+		})
+
+		Context("when the page does not finish loading before the timeout", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Result = "false"
+				Expect(page.RefreshAndWait(20 * time.Millisecond)).To(MatchError("timed out after 20ms waiting for page to load after refresh"))
+			})
+		})
+	})
+
 	Describe("#SwitchToParentFrame", func() {
 		It("should successfully instruct the session to change focus to the parent frame", func() {
 			Expect(page.SwitchToParentFrame()).To(Succeed())
@@ -565,6 +1693,144 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("#WithinFrame", func() {
+		var (
+			frame             *Selection
+			elementRepository *mocks.ElementRepository
+			apiElement        *api.Element
+		)
+
+		BeforeEach(func() {
+			apiElement = &api.Element{}
+			elementRepository = &mocks.ElementRepository{}
+			elementRepository.GetExactlyOneCall.ReturnElement = apiElement
+			frame = NewTestSelection(session, elementRepository, "#frame")
+		})
+
+		It("should call the function within the frame and then switch back to the root frame", func() {
+			var frameDuringCall *api.Element
+			Expect(page.WithinFrame(frame, func() error {
+				frameDuringCall = session.FrameCall.Frame
+				return nil
+			})).To(Succeed())
+			Expect(frameDuringCall).To(ExactlyEqual(apiElement))
+			Expect(session.FrameCall.Frame).To(BeNil())
+			Expect(session.FrameParentCall.Called).To(BeFalse())
+		})
+
+		It("should switch back to the parent frame when nested", func() {
+			Expect(page.WithinFrame(frame, func() error {
+				return page.WithinFrame(frame, func() error { return nil })
+			})).To(Succeed())
+			Expect(session.FrameParentCall.Called).To(BeTrue())
+			Expect(session.FrameCall.Frame).To(BeNil())
+		})
+
+		Context("when the function returns an error", func() {
+			It("should switch back to the root frame and return the error", func() {
+				err := page.WithinFrame(frame, func() error { return errors.New("some error") })
+				Expect(err).To(MatchError("some error"))
+				Expect(session.FrameCall.Frame).To(BeNil())
+			})
+		})
+
+		Context("when switching to the frame fails", func() {
+			It("should return an error without calling the function", func() {
+				session.FrameCall.Err = errors.New("some error")
+				called := false
+				err := page.WithinFrame(frame, func() error {
+					called = true
+					return nil
+				})
+				Expect(err).To(MatchError("failed to switch to frame referred to by selection 'CSS: #frame [single]': some error"))
+				Expect(called).To(BeFalse())
+			})
+		})
+
+		Context("when switching back to the root frame fails", func() {
+			It("should return an error", func() {
+				err := page.WithinFrame(frame, func() error {
+					session.FrameCall.Err = errors.New("some error")
+					return nil
+				})
+				Expect(err).To(MatchError("failed to switch to original page frame: some error"))
+			})
+		})
+	})
+
+	Describe("reading meta tags", func() {
+		var bus *mocks.Bus
+
+		BeforeEach(func() {
+			bus = &mocks.Bus{}
+			bus.SendCall.Result = `"some content"`
+			session.GetElementsCall.ReturnElements = []*api.Element{{ID: "meta", Session: &api.Session{Bus: bus}}}
+		})
+
+		Describe("#MetaContent", func() {
+			It("should return the content of the meta tag with the provided name", func() {
+				Expect(page.MetaContent("description")).To(Equal("some content"))
+				Expect(session.GetElementsCall.Selector).To(Equal(api.Selector{Using: "css selector", Value: `head meta[name="description"]`}))
+				Expect(bus.SendCall.Endpoint).To(Equal("element/meta/attribute/content"))
+			})
+
+			Context("when the meta tag cannot be read", func() {
+				It("should return an error", func() {
+					session.GetElementsCall.Err = errors.New("some error")
+					_, err := page.MetaContent("description")
+					Expect(err).To(MatchError(`failed to read meta 'description': failed to select element from selection 'CSS: head meta[name="description"] [single]': some error`))
+				})
+			})
+
+			Context("when the name contains quotes or backslashes", func() {
+				It("should escape them as a CSS string", func() {
+					Expect(page.MetaContent(`some "quoted" \name`)).To(Equal("some content"))
+					Expect(session.GetElementsCall.Selector.Value).To(Equal(`head meta[name="some \"quoted\" \\name"]`))
+				})
+			})
+		})
+
+		Describe("#MetaProperty", func() {
+			It("should return the content of the meta tag with the provided property", func() {
+				Expect(page.MetaProperty("og:title")).To(Equal("some content"))
+				Expect(session.GetElementsCall.Selector).To(Equal(api.Selector{Using: "css selector", Value: `head meta[property="og:title"]`}))
+			})
+
+			Context("when the meta tag cannot be read", func() {
+				It("should return an error", func() {
+					bus.SendCall.Err = errors.New("some error")
+					_, err := page.MetaProperty("og:title")
+					Expect(err).To(MatchError(`failed to read meta 'og:title': failed to retrieve attribute value for selection 'CSS: head meta[property="og:title"] [single]': some error`))
+				})
+			})
+		})
+	})
+
+	Describe("#Frames", func() {
+		It("should return a selection of all iframe and frame elements", func() {
+			session.GetElementsCall.ReturnElements = []*api.Element{{}, {}}
+			Expect(page.Frames().String()).To(Equal("selection 'CSS: iframe, frame'"))
+			Expect(page.Frames().Count()).To(Equal(2))
+			Expect(session.GetElementsCall.Selector).To(Equal(api.Selector{Using: "css selector", Value: "iframe, frame"}))
+		})
+	})
+
+	Describe("#FrameCount", func() {
+		It("should return the number of iframe and frame elements", func() {
+			session.GetElementsCall.ReturnElements = []*api.Element{{}, {}}
+			Expect(page.FrameCount()).To(Equal(2))
+			Expect(session.GetElementsCall.Selector).To(Equal(api.Selector{Using: "css selector", Value: "iframe, frame"}))
+		})
+
+		Context("when the frames cannot be retrieved", func() {
+			It("should return an error", func() {
+				session.GetElementsCall.Err = errors.New("some error")
+				_, err := page.FrameCount()
+				Expect(err).To(MatchError("failed to select elements from selection 'CSS: iframe, frame': some error"))
+			})
+		})
+	})
+
 	Describe("#SwitchToWindow", func() {
 		It("should successfully instruct the session to switch to the named window", func() {
 			Expect(page.SwitchToWindow("some name")).To(Succeed())
@@ -579,6 +1845,116 @@ var _ = Describe("Page", func() {
 		})
 	})
 
+	Describe("switching windows by title and URL", func() {
+		var (
+			windows      *windowSession
+			firstWindow  *api.Window
+			secondWindow *api.Window
+			originalPage *Page
+		)
+
+		BeforeEach(func() {
+			firstWindow = &api.Window{ID: "first"}
+			secondWindow = &api.Window{ID: "second"}
+			windows = &windowSession{
+				Session: session,
+				titles:  map[string]string{"first": "Home", "second": "Checkout"},
+				urls:    map[string]string{"first": "http://example.com/", "second": "http://example.com/checkout?step=1"},
+			}
+			session.GetWindowsCall.ReturnWindows = []*api.Window{firstWindow, secondWindow}
+			session.GetWindowCall.ReturnWindow = firstWindow
+			originalPage = NewTestPage(windows)
+		})
+
+		Describe("#SwitchToWindowByTitle", func() {
+			It("should switch to the window with the provided title", func() {
+				Expect(originalPage.SwitchToWindowByTitle("Checkout")).To(Succeed())
+				Expect(windows.current).To(Equal("second"))
+			})
+
+			Context("when no window has the provided title", func() {
+				It("should switch back to the original window and return an error", func() {
+					Expect(originalPage.SwitchToWindowByTitle("Missing")).To(MatchError("failed to find window with title 'Missing'"))
+					Expect(windows.current).To(Equal("first"))
+				})
+			})
+
+			Context("when the windows cannot be retrieved", func() {
+				It("should return an error", func() {
+					session.GetWindowsCall.Err = errors.New("some error")
+					Expect(originalPage.SwitchToWindowByTitle("Checkout")).To(MatchError("failed to find available windows: some error"))
+				})
+			})
+
+			Context("when the active window cannot be retrieved", func() {
+				It("should return an error", func() {
+					session.GetWindowCall.Err = errors.New("some error")
+					Expect(originalPage.SwitchToWindowByTitle("Checkout")).To(MatchError("failed to find active window: some error"))
+				})
+			})
+
+			Context("when switching to a window fails", func() {
+				It("should switch back to the original window and return an error", func() {
+					windows.setWindowErrs = map[string]error{"second": errors.New("some error")}
+					Expect(originalPage.SwitchToWindowByTitle("Checkout")).To(MatchError("failed to switch to window: some error"))
+					Expect(windows.current).To(Equal("first"))
+				})
+			})
+
+			Context("when switching to a window and back to the original window fails", func() {
+				It("should return an error that includes both failures", func() {
+					session.SetWindowCall.Err = errors.New("some error")
+					Expect(originalPage.SwitchToWindowByTitle("Checkout")).To(MatchError("failed to switch to window: some error (failed to switch to original window: some error)"))
+				})
+			})
+
+			Context("when the title of a window cannot be retrieved", func() {
+				It("should return an error", func() {
+					session.GetTitleCall.Err = errors.New("some error")
+					Expect(originalPage.SwitchToWindowByTitle("Checkout")).To(MatchError("failed to switch to window with title 'Checkout': failed to retrieve page title: some error"))
+				})
+			})
+
+			Context("when the title of a later window cannot be retrieved", func() {
+				It("should switch back to the original window and return an error", func() {
+					windows.titleErrs = map[string]error{"second": errors.New("some error")}
+					Expect(originalPage.SwitchToWindowByTitle("Checkout")).To(MatchError("failed to switch to window with title 'Checkout': failed to retrieve page title: some error"))
+					Expect(windows.current).To(Equal("first"))
+				})
+			})
+
+			Context("when the title of a window cannot be retrieved and switching back to the original window fails", func() {
+				It("should return an error that includes both failures", func() {
+					session.GetWindowCall.ReturnWindow = &api.Window{ID: "original"}
+					windows.titleErrs = map[string]error{"second": errors.New("some error")}
+					windows.setWindowErrs = map[string]error{"original": errors.New("some other error")}
+					Expect(originalPage.SwitchToWindowByTitle("Checkout")).To(MatchError("failed to switch to window with title 'Checkout': failed to retrieve page title: some error (failed to switch to original window: some other error)"))
+				})
+			})
+		})
+
+		Describe("#SwitchToWindowByURL", func() {
+			It("should switch to the window with a URL matching the provided pattern", func() {
+				Expect(originalPage.SwitchToWindowByURL(`/checkout\b`)).To(Succeed())
+				Expect(windows.current).To(Equal("second"))
+			})
+
+			Context("when no window has a matching URL", func() {
+				It("should switch back to the original window and return an error", func() {
+					Expect(originalPage.SwitchToWindowByURL("/missing")).To(MatchError("failed to find window with URL matching '/missing'"))
+					Expect(windows.current).To(Equal("first"))
+				})
+			})
+
+			Context("when the pattern is invalid", func() {
+				It("should return an error", func() {
+					err := originalPage.SwitchToWindowByURL("(")
+					Expect(err.Error()).To(HavePrefix("invalid URL pattern: "))
+				})
+			})
+		})
+	})
+
 	Describe("#NextWindow", func() {
 		BeforeEach(func() {
 			firstWindow := &api.Window{ID: "first window"}
@@ -796,4 +2172,128 @@ var _ = Describe("Page", func() {
 			})
 		})
 	})
+
+	Describe("#SetPollInterval", func() {
+		It("should default to polling every 100ms", func() {
+			Expect(PollInterval(page)).To(Equal(100 * time.Millisecond))
+		})
+
+		It("should set the poll interval for the page and all selections created from it", func() {
+			selection := page.Find("#selector")
+			page.SetPollInterval(time.Second)
+			Expect(PollInterval(page)).To(Equal(time.Second))
+			Expect(PollInterval(selection)).To(Equal(time.Second))
+			Expect(PollInterval(page.All("#selector").At(1))).To(Equal(time.Second))
+		})
+
+		Context("when the interval is shorter than 10ms", func() {
+			It("should poll every 10ms", func() {
+				page.SetPollInterval(time.Millisecond)
+				Expect(PollInterval(page)).To(Equal(10 * time.Millisecond))
+			})
+		})
+	})
+
+	Describe("#SetDoubleClickStabilityTimeout", func() {
+		It("should default to not waiting for stability", func() {
+			Expect(StabilityTimeout(page)).To(BeZero())
+		})
+
+		It("should set the stability timeout for the page and all selections created from it", func() {
+			selection := page.Find("#selector")
+			page.SetDoubleClickStabilityTimeout(time.Second)
+			Expect(StabilityTimeout(selection)).To(Equal(time.Second))
+			Expect(StabilityTimeout(page.All("#selector").At(1))).To(Equal(time.Second))
+		})
+	})
+
+	Describe("#SetStaleElementRetries", func() {
+		It("should default to one retry", func() {
+			Expect(StaleElementRetries(page)).To(Equal(1))
+		})
+
+		It("should set the number of retries for the page and all selections created from it", func() {
+			selection := page.Find("#selector")
+			page.SetStaleElementRetries(3)
+			Expect(StaleElementRetries(selection)).To(Equal(3))
+			Expect(StaleElementRetries(page.All("#selector").At(1))).To(Equal(3))
+		})
+
+		Context("when the number of retries is negative", func() {
+			It("should not retry", func() {
+				page.SetStaleElementRetries(-1)
+				Expect(StaleElementRetries(page)).To(BeZero())
+			})
+		})
+	})
+
+	Describe("#SetTimeouts", func() {
+		It("should successfully set all of the timeouts in milliseconds", func() {
+			Expect(page.SetTimeouts(time.Second, 2*time.Minute, 1500*time.Microsecond)).To(Succeed())
+			Expect(session.SetTimeoutsCall.Timeouts).To(Equal(api.Timeouts{Implicit: 1000, PageLoad: 120000, Script: 2}))
+		})
+
+		It("should round durations shorter than a millisecond up to one millisecond", func() {
+			Expect(page.SetTimeouts(500*time.Microsecond, 0, time.Nanosecond)).To(Succeed())
+			Expect(session.SetTimeoutsCall.Timeouts).To(Equal(api.Timeouts{Implicit: 1, Script: 1}))
+		})
+
+		Context("when the session fails to set the timeouts", func() {
+			It("should return an error", func() {
+				session.SetTimeoutsCall.Err = errors.New("some error")
+				Expect(page.SetTimeouts(time.Second, 0, 0)).To(MatchError("failed to set timeouts: some error"))
+			})
+		})
+	})
 })
+
+// windowSession reports a different title and URL for each window.
+type windowSession struct {
+	*mocks.Session
+	titles        map[string]string
+	titleErrs     map[string]error
+	setWindowErrs map[string]error
+	urls          map[string]string
+	current       string
+}
+
+func (s *windowSession) SetWindow(window *api.Window) error {
+	if err := s.Session.SetWindow(window); err != nil {
+		return err
+	}
+	if err := s.setWindowErrs[window.ID]; err != nil {
+		return err
+	}
+	s.current = window.ID
+	return nil
+}
+
+func (s *windowSession) GetTitle() (string, error) {
+	if _, err := s.Session.GetTitle(); err != nil {
+		return "", err
+	}
+	return s.titles[s.current], s.titleErrs[s.current]
+}
+
+func (s *windowSession) GetURL() (string, error) {
+	if _, err := s.Session.GetURL(); err != nil {
+		return "", err
+	}
+	return s.urls[s.current], nil
+}
+
+type navigationSession struct {
+	*mocks.Session
+	errs  []error
+	calls int
+}
+
+func (s *navigationSession) SetURL(url string) error {
+	s.calls++
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return err
+	}
+	return s.Session.SetURL(url)
+}
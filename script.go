@@ -0,0 +1,13 @@
+package agouti
+
+import "github.com/sclevine/agouti/internal/element"
+
+// elementArgument returns a reference to the provided element that may be
+// passed to JavaScript as a script argument. Both the JSON Wire Protocol and
+// W3C keys are included so that either kind of WebDriver can decode it.
+func elementArgument(selectedElement element.Element) map[string]string {
+	return map[string]string{
+		"ELEMENT":                             selectedElement.GetID(),
+		"element-6066-11e4-a52e-4f735466cecf": selectedElement.GetID(),
+	}
+}
@@ -1,11 +1,21 @@
 package agouti
 
 import (
+	"fmt"
+	"io"
+	"strings"
+
 	"github.com/sclevine/agouti/api"
 	"github.com/sclevine/agouti/internal/element"
 	"github.com/sclevine/agouti/internal/target"
 )
 
+// Protocols reported by apiSession.Protocol.
+const (
+	w3cProtocol      = "w3c"
+	jsonWireProtocol = "jsonwire"
+)
+
 type Selectors interface {
 	String() string
 }
@@ -13,6 +23,7 @@ type Selectors interface {
 type selectable struct {
 	session   apiSession
 	selectors target.Selectors
+	waits     *waitSettings
 }
 
 type apiSession interface {
@@ -24,7 +35,17 @@ type apiSession interface {
 	SetWindow(window *api.Window) error
 	SetWindowByName(name string) error
 	DeleteWindow() error
+	Fullscreen() error
+	GetWindowRect() (x, y, width, height int, err error)
+	SetWindowRect(x, y, width, height int) error
+	ExecuteCDP(command string, params map[string]interface{}, result interface{}) error
+	SetNetworkConditions(offline bool, latency, downloadThroughput, uploadThroughput int) error
+	SetPermission(name, state string) error
+	Capabilities() map[string]interface{}
+	Protocol() string
+	Keys(text string) error
 	GetScreenshot() ([]byte, error)
+	PrintPDF(options api.PrintOptions) ([]byte, error)
 	GetCookies() ([]*api.Cookie, error)
 	SetCookie(cookie *api.Cookie) error
 	DeleteCookie(name string) error
@@ -37,6 +58,11 @@ type apiSession interface {
 	Frame(frame *api.Element) error
 	FrameParent() error
 	Execute(body string, arguments []interface{}, result interface{}) error
+	ExecuteElements(body string, arguments []interface{}) ([]*api.Element, error)
+	ExecuteNestedElements(body string, arguments []interface{}) (interface{}, error)
+	SetTraceLogger(logger io.Writer)
+	EnableMetrics()
+	Metrics() map[string]api.EndpointMetric
 	Forward() error
 	Back() error
 	Refresh() error
@@ -47,6 +73,8 @@ type apiSession interface {
 	NewLogs(logType string) ([]api.Log, error)
 	GetLogTypes() ([]string, error)
 	DoubleClick() error
+	PointerDoubleClick(element *api.Element) error
+	PointerClick(element *api.Element, button api.Button) error
 	Click(button api.Button) error
 	ButtonDown(button api.Button) error
 	ButtonUp(button api.Button) error
@@ -62,130 +90,184 @@ type apiSession interface {
 	DeleteSessionStorage() error
 	SetImplicitWait(timout int) error
 	SetPageLoad(timout int) error
+	SetTimeouts(timeouts api.Timeouts) error
 	SetScriptTimeout(timout int) error
 }
 
 // Find finds exactly one element by CSS selector.
 func (s *selectable) Find(selector string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.CSS, selector).Single())
+	return newSelection(s.session, s.selectors.Append(target.CSS, selector).Single(), s.waits)
 }
 
 // FindByXPath finds exactly one element by XPath selector.
 func (s *selectable) FindByXPath(selector string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.XPath, selector).Single())
+	return newSelection(s.session, s.selectors.Append(target.XPath, selector).Single(), s.waits)
 }
 
 // FindByLink finds exactly one anchor element by its text content.
 func (s *selectable) FindByLink(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Link, text).Single())
+	return newSelection(s.session, s.selectors.Append(target.Link, text).Single(), s.waits)
 }
 
 // FindByLabel finds exactly one element by associated label text.
 func (s *selectable) FindByLabel(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Label, text).Single())
+	return newSelection(s.session, s.selectors.Append(target.Label, text).Single(), s.waits)
 }
 
 // FindByButton finds exactly one button element with the provided text.
 // Supports <button>, <input type="button">, and <input type="submit">.
 func (s *selectable) FindByButton(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Button, text).Single())
+	return newSelection(s.session, s.selectors.Append(target.Button, text).Single(), s.waits)
 }
 
 // FindByName finds exactly element with the provided name attribute.
 func (s *selectable) FindByName(name string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Name, name).Single())
+	return newSelection(s.session, s.selectors.Append(target.Name, name).Single(), s.waits)
 }
 
 // FindByClass finds exactly one element with a given CSS class.
 func (s *selectable) FindByClass(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Class, text).Single())
+	return newSelection(s.session, s.selectors.Append(target.Class, text).Single(), s.waits)
 }
 
 // FindByID finds exactly one element that has the given ID.
 func (s *selectable) FindByID(id string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.ID, id).Single())
+	return newSelection(s.session, s.selectors.Append(target.ID, id).Single(), s.waits)
+}
+
+// implicitRoleXPaths match elements that have an ARIA role without a role
+// attribute. Only the button, link, and checkbox roles are covered.
+var implicitRoleXPaths = map[string]string{
+	"button":   `.//button[not(@role)] | .//input[not(@role)][@type="button" or @type="submit" or @type="reset"]`,
+	"link":     `.//a[@href][not(@role)]`,
+	"checkbox": `.//input[not(@role)][@type="checkbox"]`,
+}
+
+// xpathLiteral returns the provided text as an XPath string literal. XPath
+// literals cannot escape quotes, so text containing both kinds of quotes is
+// joined using concat.
+func xpathLiteral(text string) string {
+	if !strings.Contains(text, `"`) {
+		return `"` + text + `"`
+	}
+	if !strings.Contains(text, "'") {
+		return "'" + text + "'"
+	}
+	return `concat("` + strings.Join(strings.Split(text, `"`), `", '"', "`) + `")`
+}
+
+func roleXPath(role string) string {
+	xpath := fmt.Sprintf(`.//*[@role=%s]`, xpathLiteral(role))
+	if implicitXPath, ok := implicitRoleXPaths[role]; ok {
+		xpath += " | " + implicitXPath
+	}
+	return xpath
+}
+
+// FindByRole finds exactly one element with the provided ARIA role within the
+// selection. Elements with a matching role attribute are always found. Elements with a matching
+// implicit role are also found for the following roles only:
+//    button:   <button>, <input type="button|submit|reset">
+//    link:     <a href="...">
+//    checkbox: <input type="checkbox">
+func (s *selectable) FindByRole(role string) *Selection {
+	return newSelection(s.session, s.selectors.Append(target.XPath, roleXPath(role)).Single(), s.waits)
+}
+
+// SetTestIDAttribute sets the attribute that FindByTestID matches, ex.
+// "data-test" or "data-cy". The default is "data-testid". Selections created
+// by FindByTestID keep the attribute that was set when they were created.
+func SetTestIDAttribute(attribute string) {
+	target.SetTestIDAttribute(attribute)
+}
+
+// FindByTestID finds exactly one element with the provided test ID, which is
+// the value of the attribute set by SetTestIDAttribute. Like Find, the
+// selector is merged into an immediately preceding CSS selector.
+func (s *selectable) FindByTestID(id string) *Selection {
+	return newSelection(s.session, s.selectors.Append(target.TestID, id).Single(), s.waits)
 }
 
 // First finds the first element by CSS selector.
 func (s *selectable) First(selector string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.CSS, selector).At(0))
+	return newSelection(s.session, s.selectors.Append(target.CSS, selector).At(0), s.waits)
 }
 
 // FirstByXPath finds the first element by XPath selector.
 func (s *selectable) FirstByXPath(selector string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.XPath, selector).At(0))
+	return newSelection(s.session, s.selectors.Append(target.XPath, selector).At(0), s.waits)
 }
 
 // FirstByLink finds the first anchor element by its text content.
 func (s *selectable) FirstByLink(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Link, text).At(0))
+	return newSelection(s.session, s.selectors.Append(target.Link, text).At(0), s.waits)
 }
 
 // FirstByLabel finds the first element by associated label text.
 func (s *selectable) FirstByLabel(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Label, text).At(0))
+	return newSelection(s.session, s.selectors.Append(target.Label, text).At(0), s.waits)
 }
 
 // FirstByButton finds the first button element with the provided text.
 // Supports <button>, <input type="button">, and <input type="submit">.
 func (s *selectable) FirstByButton(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Button, text).At(0))
+	return newSelection(s.session, s.selectors.Append(target.Button, text).At(0), s.waits)
 }
 
 // FirstByName finds the first element with the provided name attribute.
 func (s *selectable) FirstByName(name string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Name, name).At(0))
+	return newSelection(s.session, s.selectors.Append(target.Name, name).At(0), s.waits)
 }
 
 // FirstByClass finds the first element with a given CSS class.
 func (s *selectable) FirstByClass(text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Class, text).At(0))
+	return newSelection(s.session, s.selectors.Append(target.Class, text).At(0), s.waits)
 }
 
 // All finds zero or more elements by CSS selector.
 func (s *selectable) All(selector string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.CSS, selector))
+	return newMultiSelection(s.session, s.selectors.Append(target.CSS, selector), s.waits)
 }
 
 // AllByXPath finds zero or more elements by XPath selector.
 func (s *selectable) AllByXPath(selector string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.XPath, selector))
+	return newMultiSelection(s.session, s.selectors.Append(target.XPath, selector), s.waits)
 }
 
 // AllByLink finds zero or more anchor elements by their text content.
 func (s *selectable) AllByLink(text string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.Link, text))
+	return newMultiSelection(s.session, s.selectors.Append(target.Link, text), s.waits)
 }
 
 // AllByLabel finds zero or more elements by associated label text.
 func (s *selectable) AllByLabel(text string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.Label, text))
+	return newMultiSelection(s.session, s.selectors.Append(target.Label, text), s.waits)
 }
 
 // AllByButton finds zero or more button elements with the provided text.
 // Supports <button>, <input type="button">, and <input type="submit">.
 func (s *selectable) AllByButton(text string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.Button, text))
+	return newMultiSelection(s.session, s.selectors.Append(target.Button, text), s.waits)
 }
 
 // AllByName finds zero or more elements with the provided name attribute.
 func (s *selectable) AllByName(name string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.Name, name))
+	return newMultiSelection(s.session, s.selectors.Append(target.Name, name), s.waits)
 }
 
 // AllByClass finds zero or more elements with a given CSS class.
 func (s *selectable) AllByClass(text string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.Class, text))
+	return newMultiSelection(s.session, s.selectors.Append(target.Class, text), s.waits)
 }
 
 // AllByID finds zero or more elements with a given ID.
 func (s *selectable) AllByID(text string) *MultiSelection {
-	return newMultiSelection(s.session, s.selectors.Append(target.ID, text))
+	return newMultiSelection(s.session, s.selectors.Append(target.ID, text), s.waits)
 }
 
 // FirstByClass finds the first element with a given CSS class.
 func (s *selectable) FindForAppium(selectorType string, text string) *Selection {
-	return newSelection(s.session, s.selectors.Append(target.Class, text).At(0))
+	return newSelection(s.session, s.selectors.Append(target.Class, text).At(0), s.waits)
 }
 
 func (s *selectable) Selectors() Selectors {
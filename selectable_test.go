@@ -29,6 +29,25 @@ var _ = Describe("Selectable", func() {
 		})
 	})
 
+	Describe("branching from a shared parent", func() {
+		It("should not affect sibling branches when both children are merged into a CSS parent", func() {
+			parent := page.All("#parent")
+			firstChild := parent.Find("#first")
+			secondChild := parent.Find("#second")
+			Expect(firstChild.String()).To(Equal("selection 'CSS: #parent #first [single]'"))
+			Expect(secondChild.String()).To(Equal("selection 'CSS: #parent #second [single]'"))
+			Expect(parent.String()).To(Equal("selection 'CSS: #parent'"))
+		})
+
+		It("should not affect sibling branches when the parent is an XPath selection", func() {
+			parent := page.AllByXPath("//parent")
+			firstChild := parent.Find("#first")
+			secondChild := parent.Find("#second")
+			Expect(firstChild.String()).To(Equal("selection 'XPath: //parent | CSS: #first [single]'"))
+			Expect(secondChild.String()).To(Equal("selection 'XPath: //parent | CSS: #second [single]'"))
+		})
+	})
+
 	Describe("#FindByXPath", func() {
 		It("should apply a single XPath selector and return a selection with the same session", func() {
 			Expect(page.FindByXPath("selector").String()).To(Equal("selection 'XPath: selector [single]'"))
@@ -71,6 +90,72 @@ var _ = Describe("Selectable", func() {
 		})
 	})
 
+	Describe("#FindByRole", func() {
+		It("should apply a single XPath selector matching the role attribute and return a selection with the same session", func() {
+			Expect(page.FindByRole("dialog").String()).To(Equal(`selection 'XPath: .//*[@role="dialog"] [single]'`))
+			Expect(page.FindByRole("dialog").Elements()).To(ContainElement(&api.Element{Session: session}))
+		})
+
+		It("should also match elements with an implicit button role", func() {
+			Expect(page.FindByRole("button").String()).To(Equal(`selection 'XPath: .//*[@role="button"] | .//button[not(@role)] | .//input[not(@role)][@type="button" or @type="submit" or @type="reset"] [single]'`))
+		})
+
+		It("should also match elements with an implicit link role", func() {
+			Expect(page.FindByRole("link").String()).To(Equal(`selection 'XPath: .//*[@role="link"] | .//a[@href][not(@role)] [single]'`))
+		})
+
+		It("should also match elements with an implicit checkbox role", func() {
+			Expect(page.FindByRole("checkbox").String()).To(Equal(`selection 'XPath: .//*[@role="checkbox"] | .//input[not(@role)][@type="checkbox"] [single]'`))
+		})
+
+		It("should only match elements within the parent selection", func() {
+			selection := page.Find("#form").FindByRole("dialog")
+			Expect(selection.String()).To(Equal(`selection 'CSS: #form [single] | XPath: .//*[@role="dialog"] [single]'`))
+			Expect(selection.Elements()).To(ContainElement(&api.Element{Session: session}))
+			Expect(bus.SendCall.Endpoint).To(Equal("element/elements"))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"using": "xpath", "value": ".//*[@role=\"dialog\"]"}`))
+		})
+
+		It("should quote roles that contain double quotes", func() {
+			Expect(page.FindByRole(`some"role`).String()).To(Equal(`selection 'XPath: .//*[@role='some"role'] [single]'`))
+		})
+
+		It("should quote roles that contain both kinds of quotes", func() {
+			Expect(page.FindByRole(`a"b'c`).String()).To(Equal(`selection 'XPath: .//*[@role=concat("a", '"', "b'c")] [single]'`))
+		})
+	})
+
+	Describe("#FindByTestID", func() {
+		It("should apply a single test ID selector and return a selection with the same session", func() {
+			Expect(page.FindByTestID("selector").String()).To(Equal(`selection 'TestID: selector [single]'`))
+			Expect(page.FindByTestID("selector").Elements()).To(ContainElement(&api.Element{Session: session}))
+			Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"using": "css selector", "value": "[data-testid=\"selector\"]"}`))
+		})
+
+		It("should merge into a preceding CSS selector", func() {
+			Expect(page.All("#form").FindByTestID("selector").String()).To(Equal(`selection 'CSS: #form [data-testid="selector"] [single]'`))
+		})
+
+		Context("when the test ID attribute is changed", func() {
+			AfterEach(func() {
+				SetTestIDAttribute("data-testid")
+			})
+
+			It("should match the new attribute", func() {
+				SetTestIDAttribute("data-cy")
+				Expect(page.FindByTestID("selector").Elements()).To(ContainElement(&api.Element{Session: session}))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"using": "css selector", "value": "[data-cy=\"selector\"]"}`))
+			})
+
+			It("should not change existing selections", func() {
+				selection := page.FindByTestID("selector")
+				SetTestIDAttribute("data-cy")
+				Expect(selection.Elements()).To(ContainElement(&api.Element{Session: session}))
+				Expect(bus.SendCall.BodyJSON).To(MatchJSON(`{"using": "css selector", "value": "[data-testid=\"selector\"]"}`))
+			})
+		})
+	})
+
 	Describe("#First", func() {
 		It("should apply a zero-indexed CSS selector and return a selection with the same session", func() {
 			Expect(page.First("selector").String()).To(Equal("selection 'CSS: selector [0]'"))
@@ -2,6 +2,7 @@ package agouti
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/sclevine/agouti/api"
 	"github.com/sclevine/agouti/internal/element"
@@ -32,9 +33,9 @@ type elementRepository interface {
 	GetExactlyOne() (element.Element, error)
 }
 
-func newSelection(session apiSession, selectors target.Selectors) *Selection {
+func newSelection(session apiSession, selectors target.Selectors, waits *waitSettings) *Selection {
 	return &Selection{
-		selectable{session, selectors},
+		selectable{session, selectors, waits},
 		&element.Repository{
 			Client:    session,
 			Selectors: selectors,
@@ -48,6 +49,16 @@ func (s *Selection) String() string {
 	return fmt.Sprintf("selection '%s'", s.selectors)
 }
 
+// Clone returns a copy of the selection with its own copy of the selector
+// chain. Selections may be safely branched without Clone, but Clone makes the
+// intent explicit when a base selection is reused, ex.
+//    a := base.Clone().Find("#x")
+//    b := base.Clone().Find("#y")
+func (s *Selection) Clone() *Selection {
+	selectors := append(target.Selectors(nil), s.selectors...)
+	return newSelection(s.session, selectors, s.waits)
+}
+
 // Elements returns a []*api.Element that can be used to send direct commands
 // to WebDriver elements. See: https://code.google.com/p/selenium/wiki/JsonWireProtocol
 func (s *Selection) Elements() ([]*api.Element, error) {
@@ -63,15 +74,84 @@ func (s *Selection) Elements() ([]*api.Element, error) {
 }
 
 // Count returns the number of elements that the selection refers to.
+// A selection with no selectors is an error; see CountOrZero.
 func (s *Selection) Count() (int, error) {
 	elements, err := s.elements.Get()
 	if err != nil {
-		return 0, fmt.Errorf("failed to select elements from %s: %s", s, err)
+		return 0, fmt.Errorf("failed to select elements from %s: %w", s, err)
 	}
 
 	return len(elements), nil
 }
 
+// CountOrZero returns the number of elements that the selection refers to.
+// Unlike Count, which fails with an "empty selection" error when the selection
+// has no selectors, CountOrZero returns zero without an error in that case.
+// Errors retrieving the elements of a non-empty selection are still returned.
+func (s *Selection) CountOrZero() (int, error) {
+	if len(s.selectors) == 0 {
+		return 0, nil
+	}
+
+	return s.Count()
+}
+
+// IsPresent returns whether the selection refers to at least one element.
+// Unlike Count, IsPresent returns false without an error when any part of
+// the selection simply matches nothing.
+func (s *Selection) IsPresent() (bool, error) {
+	elements, err := s.elements.Get()
+	if err != nil {
+		if isNotFoundError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to select elements from %s: %w", s, err)
+	}
+
+	return len(elements) > 0, nil
+}
+
+func isNotFoundError(err error) bool {
+	return IsNoSuchElement(err) || strings.HasSuffix(err.Error(), "element index out of range")
+}
+
+// Snapshot resolves the elements that the selection refers to once and
+// returns a selection backed by that fixed list of elements. Reading from
+// the snapshot does not query the WebDriver for the elements again, which
+// is useful for read-heavy assertions on expensive selectors.
+//
+// The snapshot does not follow changes to the DOM. If the elements are
+// removed or replaced, methods called on the snapshot will fail.
+// Selectors applied to the snapshot (ex. Find) are resolved from scratch.
+func (s *Selection) Snapshot() (*Selection, error) {
+	elements, err := s.elements.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to select elements from %s: %w", s, err)
+	}
+
+	return &Selection{
+		selectable{s.session, s.selectors, s.waits},
+		&element.Snapshot{Elements: elements},
+	}, nil
+}
+
+// ShadowRoot returns a selection scoped to the shadow root of exactly one
+// element, such as a web component host. Call Find, All, or any other
+// selector method on the returned selection to select elements within the
+// shadow root. An error is returned if the element has no shadow root.
+func (s *Selection) ShadowRoot() (*Selection, error) {
+	host, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return nil, fmt.Errorf("failed to select element from %s: %w", s, err)
+	}
+
+	if _, err := host.GetShadowRoot(); err != nil {
+		return nil, fmt.Errorf("failed to retrieve shadow root of %s: %w", s, err)
+	}
+
+	return newSelection(s.session, s.selectors.ShadowRoot(), s.waits), nil
+}
+
 // EqualsElement returns whether or not two selections of exactly
 // one element refer to the same element.
 func (s *Selection) EqualsElement(other interface{}) (bool, error) {
@@ -86,17 +166,17 @@ func (s *Selection) EqualsElement(other interface{}) (bool, error) {
 
 	selectedElement, err := s.elements.GetExactlyOne()
 	if err != nil {
-		return false, fmt.Errorf("failed to select element from %s: %s", s, err)
+		return false, fmt.Errorf("failed to select element from %s: %w", s, err)
 	}
 
 	otherElement, err := otherSelection.elements.GetExactlyOne()
 	if err != nil {
-		return false, fmt.Errorf("failed to select element from %s: %s", other, err)
+		return false, fmt.Errorf("failed to select element from %s: %w", other, err)
 	}
 
 	equal, err := selectedElement.IsEqualTo(otherElement.(*api.Element))
 	if err != nil {
-		return false, fmt.Errorf("failed to compare %s to %s: %s", s, other, err)
+		return false, fmt.Errorf("failed to compare %s to %s: %w", s, other, err)
 	}
 
 	return equal, nil
@@ -106,11 +186,11 @@ func (s *Selection) EqualsElement(other interface{}) (bool, error) {
 func (s *Selection) MouseToElement() error {
 	selectedElement, err := s.elements.GetExactlyOne()
 	if err != nil {
-		return fmt.Errorf("failed to select element from %s: %s", s, err)
+		return fmt.Errorf("failed to select element from %s: %w", s, err)
 	}
 
 	if err := s.session.MoveTo(selectedElement.(*api.Element), nil); err != nil {
-		return fmt.Errorf("failed to move mouse to element for %s: %s", s, err)
+		return fmt.Errorf("failed to move mouse to element for %s: %w", s, err)
 	}
 
 	return nil
@@ -1,12 +1,21 @@
 package agouti
 
 import (
+	"encoding/base64"
 	"fmt"
+	"io/ioutil"
+	"mime"
+	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/sclevine/agouti/api"
 	"github.com/sclevine/agouti/internal/element"
 	"github.com/sclevine/agouti/internal/target"
+	"github.com/sclevine/agouti/key"
 )
 
 type actionsFunc func(element.Element) error
@@ -14,7 +23,7 @@ type actionsFunc func(element.Element) error
 func (s *Selection) forEachElement(actions actionsFunc) error {
 	elements, err := s.elements.GetAtLeastOne()
 	if err != nil {
-		return fmt.Errorf("failed to select elements from %s: %s", s, err)
+		return fmt.Errorf("failed to select elements from %s: %w", s, err)
 	}
 
 	for _, element := range elements {
@@ -29,78 +38,428 @@ func (s *Selection) forEachElement(actions actionsFunc) error {
 func (s *Selection) Click() error {
 	return s.forEachElement(func(selectedElement element.Element) error {
 		if err := selectedElement.Click(); err != nil {
-			return fmt.Errorf("failed to click on %s: %s", s, err)
+			return fmt.Errorf("failed to click on %s: %w", s, err)
 		}
 		return nil
 	})
 }
 
 // DoubleClick double-clicks on all of the elements that the selection refers to.
+// W3C WebDrivers are sent a pointer action sequence, while other WebDrivers are
+// sent legacy mouse commands.
+//
+// If a stability timeout is set using Page.SetDoubleClickStabilityTimeout,
+// DoubleClick first waits for each element to stop moving.
 func (s *Selection) DoubleClick() error {
+	w3c := s.session.Protocol() == w3cProtocol
 	return s.forEachElement(func(selectedElement element.Element) error {
+		if err := s.waitUntilStable(selectedElement); err != nil {
+			return fmt.Errorf("failed to double-click on %s: %w", s, err)
+		}
+
+		if w3c {
+			if err := s.session.PointerDoubleClick(selectedElement.(*api.Element)); err != nil {
+				return fmt.Errorf("failed to double-click on %s: %w", s, err)
+			}
+			return nil
+		}
+
 		if err := s.session.MoveTo(selectedElement.(*api.Element), nil); err != nil {
-			return fmt.Errorf("failed to move mouse to %s: %s", s, err)
+			return fmt.Errorf("failed to move mouse to %s: %w", s, err)
 		}
 		if err := s.session.DoubleClick(); err != nil {
-			return fmt.Errorf("failed to double-click on %s: %s", s, err)
+			return fmt.Errorf("failed to double-click on %s: %w", s, err)
+		}
+		return nil
+	})
+}
+
+const elementRectScript = `
+var rect = arguments[0].getBoundingClientRect();
+return [rect.left, rect.top, rect.width, rect.height];`
+
+// waitUntilStable waits until two consecutive reads of the element's bounding
+// rectangle, one poll interval apart, are equal. It returns immediately if no
+// stability timeout is set.
+func (s *Selection) waitUntilStable(selectedElement element.Element) error {
+	timeout := s.stabilityTimeout()
+	if timeout == 0 {
+		return nil
+	}
+
+	var (
+		lastRect, rect []float64
+		scriptErr      error
+	)
+	arguments := []interface{}{elementArgument(selectedElement)}
+	stable := s.waitFor(timeout, func() bool {
+		lastRect, rect = rect, nil
+		if err := s.session.Execute(elementRectScript, arguments, &rect); err != nil {
+			scriptErr = err
+			return true
+		}
+		return lastRect != nil && reflect.DeepEqual(lastRect, rect)
+	})
+
+	if scriptErr != nil {
+		return scriptErr
+	}
+	if !stable {
+		return fmt.Errorf("element did not stop moving within %s", timeout)
+	}
+	return nil
+}
+
+const (
+	setNavigationMarkerScript = "window.__agoutiNavigationMarker = true;"
+	reloadedScript            = `return !window.__agoutiNavigationMarker && document.readyState === "complete";`
+)
+
+// ClickAndWaitForNavigation clicks on exactly one element and waits until
+// either the page URL changes or the current document is replaced by a newly
+// loaded one. An error is returned if the click succeeds but no navigation
+// occurs before the timeout elapses.
+func (s *Selection) ClickAndWaitForNavigation(timeout time.Duration) error {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return fmt.Errorf("failed to select element from %s: %w", s, err)
+	}
+
+	startURL, err := s.session.GetURL()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve URL: %w", err)
+	}
+
+	if err := s.session.Execute(setNavigationMarkerScript, nil, nil); err != nil {
+		return fmt.Errorf("failed to mark current document: %w", err)
+	}
+
+	if err := selectedElement.Click(); err != nil {
+		return fmt.Errorf("failed to click on %s: %w", s, err)
+	}
+
+	navigated := s.waitFor(timeout, func() bool {
+		if url, err := s.session.GetURL(); err == nil && url != startURL {
+			return true
+		}
+		var reloaded bool
+		err := s.session.Execute(reloadedScript, nil, &reloaded)
+		return err == nil && reloaded
+	})
+
+	if !navigated {
+		return fmt.Errorf("clicked on %s but no navigation occurred within %s", s, timeout)
+	}
+	return nil
+}
+
+const highlightScript = `
+var element = arguments[0], outline = element.style.outline;
+element.style.outline = "3px solid #ff00ff";
+setTimeout(function() { element.style.outline = outline; }, 1000);`
+
+// Highlight briefly outlines exactly one element in the browser so that it
+// can be identified while watching a test run. The original outline is
+// restored after a second. This is intended as a debugging aid.
+func (s *Selection) Highlight() error {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return fmt.Errorf("failed to select element from %s: %w", s, err)
+	}
+
+	arguments := []interface{}{elementArgument(selectedElement)}
+	if err := s.session.Execute(highlightScript, arguments, nil); err != nil {
+		return fmt.Errorf("failed to highlight %s: %w", s, err)
+	}
+	return nil
+}
+
+// RightClick moves the mouse to exactly one element and right-clicks on it,
+// which opens its context menu. On W3C WebDrivers, this uses a pointer action.
+func (s *Selection) RightClick() error {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return fmt.Errorf("failed to right-click '%s': %w", s.selectors, err)
+	}
+
+	if s.session.Protocol() == w3cProtocol {
+		if err := s.session.PointerClick(selectedElement.(*api.Element), api.RightButton); err != nil {
+			return fmt.Errorf("failed to right-click '%s': %w", s.selectors, err)
 		}
 		return nil
+	}
+
+	if err := s.session.MoveTo(selectedElement.(*api.Element), nil); err != nil {
+		return fmt.Errorf("failed to right-click '%s': %w", s.selectors, err)
+	}
+
+	if err := s.session.Click(api.RightButton); err != nil {
+		return fmt.Errorf("failed to right-click '%s': %w", s.selectors, err)
+	}
+	return nil
+}
+
+// ClickAtOffset moves the mouse to the provided offset in pixels from the
+// top-left corner of exactly one element and clicks the left mouse button.
+// This is useful for clicking on a specific point within a large element,
+// such as a cell of a canvas grid.
+func (s *Selection) ClickAtOffset(x, y int) error {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return fmt.Errorf("failed to click at offset (%d, %d) within '%s': %w", x, y, s.selectors, err)
+	}
+
+	offset := api.XYOffset{X: x, Y: y}
+	if err := s.session.MoveTo(selectedElement.(*api.Element), offset); err != nil {
+		return fmt.Errorf("failed to click at offset (%d, %d) within '%s': %w", x, y, s.selectors, err)
+	}
+
+	if err := s.session.Click(api.LeftButton); err != nil {
+		return fmt.Errorf("failed to click at offset (%d, %d) within '%s': %w", x, y, s.selectors, err)
+	}
+	return nil
+}
+
+// HoverAndGetText moves the mouse over exactly one element, waits for the
+// tooltip matching the provided CSS selector to become visible, and returns the
+// tooltip text. The tooltip selector is relative to the page rather than to the
+// selection, since tooltips are usually attached to the end of the document.
+func (s *Selection) HoverAndGetText(tooltipSelector string, timeout time.Duration) (string, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return "", fmt.Errorf("failed to select element from %s: %w", s, err)
+	}
+
+	if err := s.session.MoveTo(selectedElement.(*api.Element), nil); err != nil {
+		return "", fmt.Errorf("failed to move mouse to %s: %w", s, err)
+	}
+
+	page := &selectable{session: s.session, waits: s.waits}
+	tooltip := page.Find(tooltipSelector)
+	visible := s.waitFor(timeout, func() bool {
+		visible, err := tooltip.Visible()
+		return err == nil && visible
 	})
+	if !visible {
+		return "", fmt.Errorf("timed out after %s waiting for tooltip '%s' after hovering over '%s'", timeout, tooltipSelector, s.selectors)
+	}
+
+	return tooltip.Text()
 }
 
 // Clear clears all fields the selection refers to.
 func (s *Selection) Clear() error {
         return s.forEachElement(func(selectedElement element.Element) error {
                 if err := selectedElement.Clear(); err != nil {
-                        return fmt.Errorf("failed to clear %s: %s", s, err)
+                        return fmt.Errorf("failed to clear %s: %w", s, err)
                 }
                 return nil
         })
 }
 
+// ForceClear clears all fields the selection refers to by selecting their
+// contents with Ctrl+A (Cmd+A on macOS) and pressing Delete. This is useful
+// for custom inputs that the standard Clear fails to clear reliably.
+func (s *Selection) ForceClear() error {
+	selectAllModifier := key.Control
+	if s.isMacPlatform() {
+		selectAllModifier = key.Command
+	}
+	keys := selectAllModifier + "a" + key.Null + key.Delete
+
+	return s.forEachElement(func(selectedElement element.Element) error {
+		if err := selectedElement.Value(keys); err != nil {
+			return fmt.Errorf("failed to force clear %s: %w", s, err)
+		}
+		return nil
+	})
+}
+
+func (s *Selection) isMacPlatform() bool {
+	capabilities := s.session.Capabilities()
+	for _, name := range []string{"platformName", "platform"} {
+		platform, _ := capabilities[name].(string)
+		platform = strings.ToLower(platform)
+		if strings.Contains(platform, "mac") || strings.Contains(platform, "darwin") {
+			return true
+		}
+	}
+	return false
+}
+
 // Fill fills all of the fields the selection refers to with the provided text.
 func (s *Selection) Fill(text string) error {
 	return s.forEachElement(func(selectedElement element.Element) error {
 		if err := selectedElement.Clear(); err != nil {
-			return fmt.Errorf("failed to clear %s: %s", s, err)
+			return fmt.Errorf("failed to clear %s: %w", s, err)
+		}
+		if err := selectedElement.Value(text); err != nil {
+			return fmt.Errorf("failed to enter text into %s: %w", s, err)
+		}
+		return nil
+	})
+}
+
+// FillAndVerify fills all of the fields the selection refers to with the
+// provided text, like Fill, and then reads back the value of each field to
+// confirm that no keystrokes were dropped or intercepted. For fields that
+// transform their input, such as masked or auto-formatted inputs, use
+// FillAndVerifyFunc.
+func (s *Selection) FillAndVerify(text string) error {
+	return s.FillAndVerifyFunc(text, func(expected, actual string) bool {
+		return expected == actual
+	})
+}
+
+// FillAndVerifyFunc is like FillAndVerify, but the value read back from each
+// field is accepted if the provided function returns true for the text and
+// that value. For example, to fill a phone number field that inserts dashes:
+//    selection.FillAndVerifyFunc("5551234", func(expected, actual string) bool {
+//        return strings.Replace(actual, "-", "", -1) == expected
+//    })
+func (s *Selection) FillAndVerifyFunc(text string, matches func(expected, actual string) bool) error {
+	return s.forEachElement(func(selectedElement element.Element) error {
+		if err := selectedElement.Clear(); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", s, err)
 		}
 		if err := selectedElement.Value(text); err != nil {
-			return fmt.Errorf("failed to enter text into %s: %s", s, err)
+			return fmt.Errorf("failed to enter text into %s: %w", s, err)
+		}
+		value, err := selectedElement.GetProperty("value")
+		if err != nil {
+			return fmt.Errorf("failed to read value of %s: %w", s, err)
+		}
+		if !matches(text, value) {
+			return fmt.Errorf("failed to fill %s: value mismatch after fill: expected '%s' got '%s'", s, text, value)
+		}
+		return nil
+	})
+}
+
+const fillReactScript = `
+var element = arguments[0], text = arguments[1];
+var prototype = element instanceof HTMLTextAreaElement ? HTMLTextAreaElement.prototype :
+	element instanceof HTMLSelectElement ? HTMLSelectElement.prototype : HTMLInputElement.prototype;
+Object.getOwnPropertyDescriptor(prototype, "value").set.call(element, text);
+element.dispatchEvent(new Event("input", {bubbles: true}));
+element.dispatchEvent(new Event("change", {bubbles: true}));`
+
+// FillReact fills all of the fields the selection refers to with the provided
+// text using JavaScript, and then dispatches input and change events. Unlike
+// Fill, the value is set using the native value setter, so that the change is
+// registered by frameworks that track input values, such as React controlled
+// inputs. Use Fill for fields that respond to ordinary typing.
+func (s *Selection) FillReact(text string) error {
+	return s.forEachElement(func(selectedElement element.Element) error {
+		arguments := []interface{}{elementArgument(selectedElement), text}
+		if err := s.session.Execute(fillReactScript, arguments, nil); err != nil {
+			return fmt.Errorf("failed to enter text into %s: %w", s, err)
 		}
 		return nil
 	})
 }
 
+// FillAndSubmit fills exactly one field with the provided text and then
+// submits the form that contains it. The element is only selected once.
+func (s *Selection) FillAndSubmit(text string) error {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return fmt.Errorf("failed to fill and submit '%s': %w", s.selectors, err)
+	}
+
+	if err := selectedElement.Clear(); err != nil {
+		return fmt.Errorf("failed to fill and submit '%s': %w", s.selectors, err)
+	}
+
+	if err := selectedElement.Value(text); err != nil {
+		return fmt.Errorf("failed to fill and submit '%s': %w", s.selectors, err)
+	}
+
+	if err := s.submitElement(selectedElement, s.session.Protocol() == w3cProtocol); err != nil {
+		return fmt.Errorf("failed to fill and submit '%s': %w", s.selectors, err)
+	}
+	return nil
+}
+
 // UploadFile uploads the provided file to all selected <input type="file" />.
 // The provided filename may be a relative or absolute path.
 // Returns an error if elements of any other type are in the selection.
 func (s *Selection) UploadFile(filename string) error {
 	absFilePath, err := filepath.Abs(filename)
 	if err != nil {
-		return fmt.Errorf("failed to find absolute path for filename: %s", err)
+		return fmt.Errorf("failed to find absolute path for filename: %w", err)
 	}
 	return s.forEachElement(func(selectedElement element.Element) error {
 		tagName, err := selectedElement.GetName()
 		if err != nil {
-			return fmt.Errorf("failed to determine tag name of %s: %s", s, err)
+			return fmt.Errorf("failed to determine tag name of %s: %w", s, err)
 		}
 		if tagName != "input" {
 			return fmt.Errorf("element for %s is not an input element", s)
 		}
 		inputType, err := selectedElement.GetAttribute("type")
 		if err != nil {
-			return fmt.Errorf("failed to determine type attribute of %s: %s", s, err)
+			return fmt.Errorf("failed to determine type attribute of %s: %w", s, err)
 		}
 		if inputType != "file" {
 			return fmt.Errorf("element for %s is not a file uploader", s)
 		}
 		if err := selectedElement.Value(absFilePath); err != nil {
-			return fmt.Errorf("failed to enter text into %s: %s", s, err)
+			return fmt.Errorf("failed to enter text into %s: %w", s, err)
 		}
 		return nil
 	})
 }
 
+const dropFileScript = `
+var element = arguments[0], name = arguments[1], type = arguments[2], content = atob(arguments[3]);
+var bytes = new Uint8Array(content.length);
+for (var i = 0; i < content.length; i++) {
+	bytes[i] = content.charCodeAt(i);
+}
+var data = new DataTransfer();
+data.items.add(new File([bytes], name, {type: type}));
+["dragenter", "dragover", "drop"].forEach(function(eventType) {
+	element.dispatchEvent(new DragEvent(eventType, {dataTransfer: data, bubbles: true, cancelable: true}));
+});`
+
+// DropFile drops the provided file onto exactly one element, such as the drop
+// zone of a drag-and-drop upload widget. The file is read and sent to the
+// browser, and dragenter, dragover, and drop events carrying the file are
+// dispatched to the element. The provided filename may be a relative or
+// absolute path. Use UploadFile for <input type="file" /> elements.
+func (s *Selection) DropFile(filename string) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return fmt.Errorf("failed to drop file onto '%s': %w", s.selectors, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("failed to drop file onto '%s': %s is a directory", s.selectors, filename)
+	}
+
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to drop file onto '%s': %w", s.selectors, err)
+	}
+
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return fmt.Errorf("failed to drop file onto '%s': %w", s.selectors, err)
+	}
+
+	name := filepath.Base(filename)
+	arguments := []interface{}{
+		elementArgument(selectedElement),
+		name,
+		mime.TypeByExtension(filepath.Ext(name)),
+		base64.StdEncoding.EncodeToString(content),
+	}
+	if err := s.session.Execute(dropFileScript, arguments, nil); err != nil {
+		return fmt.Errorf("failed to drop file onto '%s': %w", s.selectors, err)
+	}
+	return nil
+}
+
 // Check checks all of the unchecked checkboxes that the selection refers to.
 func (s *Selection) Check() error {
 	return s.setChecked(true)
@@ -115,7 +474,7 @@ func (s *Selection) setChecked(checked bool) error {
 	return s.forEachElement(func(selectedElement element.Element) error {
 		elementType, err := selectedElement.GetAttribute("type")
 		if err != nil {
-			return fmt.Errorf("failed to retrieve type attribute of %s: %s", s, err)
+			return fmt.Errorf("failed to retrieve type attribute of %s: %w", s, err)
 		}
 
 		if elementType != "checkbox" {
@@ -124,12 +483,12 @@ func (s *Selection) setChecked(checked bool) error {
 
 		elementChecked, err := selectedElement.IsSelected()
 		if err != nil {
-			return fmt.Errorf("failed to retrieve state of %s: %s", s, err)
+			return fmt.Errorf("failed to retrieve state of %s: %w", s, err)
 		}
 
 		if elementChecked != checked {
 			if err := selectedElement.Click(); err != nil {
-				return fmt.Errorf("failed to click on %s: %s", s, err)
+				return fmt.Errorf("failed to click on %s: %w", s, err)
 			}
 		}
 		return nil
@@ -144,7 +503,7 @@ func (s *Selection) Select(text string) error {
 		optionToSelect := target.Selector{Type: target.XPath, Value: optionXPath}
 		options, err := selectedElement.GetElements(optionToSelect.API())
 		if err != nil {
-			return fmt.Errorf("failed to select specified option for %s: %s", s, err)
+			return fmt.Errorf("failed to select specified option for %s: %w", s, err)
 		}
 
 		if len(options) == 0 {
@@ -153,7 +512,7 @@ func (s *Selection) Select(text string) error {
 
 		for _, option := range options {
 			if err := option.Click(); err != nil {
-				return fmt.Errorf(`failed to click on option with text "%s" for %s: %s`, text, s, err)
+				return fmt.Errorf(`failed to click on option with text "%s" for %s: %w`, text, s, err)
 			}
 		}
 		return nil
@@ -162,15 +521,49 @@ func (s *Selection) Select(text string) error {
 
 // Submit submits all selected forms. The selection may refer to a form itself
 // or any input element contained within a form.
+//
+// W3C WebDrivers do not support the submit endpoint, so for these WebDrivers
+// the enclosing form is located and submitted using JavaScript. If the element
+// is not within a form, Enter is sent to the element instead.
 func (s *Selection) Submit() error {
+	w3c := s.session.Protocol() == w3cProtocol
 	return s.forEachElement(func(selectedElement element.Element) error {
-		if err := selectedElement.Submit(); err != nil {
-			return fmt.Errorf("failed to submit %s: %s", s, err)
+		if err := s.submitElement(selectedElement, w3c); err != nil {
+			return fmt.Errorf("failed to submit %s: %w", s, err)
 		}
 		return nil
 	})
 }
 
+const submitFormScript = `
+var element = arguments[0];
+var form = element.tagName === "FORM" ? element : (element.form || element.closest("form"));
+if (!form) {
+	return false;
+}
+if (form.requestSubmit) {
+	form.requestSubmit();
+} else {
+	form.submit();
+}
+return true;`
+
+func (s *Selection) submitElement(selectedElement element.Element, w3c bool) error {
+	if !w3c {
+		return selectedElement.Submit()
+	}
+
+	var submitted bool
+	arguments := []interface{}{elementArgument(selectedElement)}
+	if err := s.session.Execute(submitFormScript, arguments, &submitted); err != nil {
+		return err
+	}
+	if submitted {
+		return nil
+	}
+	return selectedElement.Value(key.Enter)
+}
+
 // Tap performs the provided Tap event on each element in the selection.
 func (s *Selection) Tap(event Tap) error {
 	var touchFunc func(*api.Element) error
@@ -187,7 +580,7 @@ func (s *Selection) Tap(event Tap) error {
 
 	return s.forEachElement(func(selectedElement element.Element) error {
 		if err := touchFunc(selectedElement.(*api.Element)); err != nil {
-			return fmt.Errorf("failed to %s on %s: %s", event, s, err)
+			return fmt.Errorf("failed to %s on %s: %w", event, s, err)
 		}
 		return nil
 	})
@@ -211,10 +604,10 @@ func (s *Selection) Touch(event Touch) error {
 	return s.forEachElement(func(selectedElement element.Element) error {
 		x, y, err := selectedElement.GetLocation()
 		if err != nil {
-			return fmt.Errorf("failed to retrieve location of %s: %s", s, err)
+			return fmt.Errorf("failed to retrieve location of %s: %w", s, err)
 		}
 		if err := touchFunc(x, y); err != nil {
-			return fmt.Errorf("failed to flick finger on %s: %s", s, err)
+			return fmt.Errorf("failed to flick finger on %s: %w", s, err)
 		}
 		return nil
 	})
@@ -225,11 +618,11 @@ func (s *Selection) Touch(event Touch) error {
 func (s *Selection) FlickFinger(xOffset, yOffset int, speed uint) error {
 	selectedElement, err := s.elements.GetExactlyOne()
 	if err != nil {
-		return fmt.Errorf("failed to select element from %s: %s", s, err)
+		return fmt.Errorf("failed to select element from %s: %w", s, err)
 	}
 
 	if err := s.session.TouchFlick(selectedElement.(*api.Element), api.XYOffset{X: xOffset, Y: yOffset}, api.ScalarSpeed(speed)); err != nil {
-		return fmt.Errorf("failed to flick finger on %s: %s", s, err)
+		return fmt.Errorf("failed to flick finger on %s: %w", s, err)
 	}
 	return nil
 }
@@ -239,11 +632,11 @@ func (s *Selection) FlickFinger(xOffset, yOffset int, speed uint) error {
 func (s *Selection) ScrollFinger(xOffset, yOffset int) error {
 	selectedElement, err := s.elements.GetExactlyOne()
 	if err != nil {
-		return fmt.Errorf("failed to select element from %s: %s", s, err)
+		return fmt.Errorf("failed to select element from %s: %w", s, err)
 	}
 
 	if err := s.session.TouchScroll(selectedElement.(*api.Element), api.XYOffset{X: xOffset, Y: yOffset}); err != nil {
-		return fmt.Errorf("failed to scroll finger on %s: %s", s, err)
+		return fmt.Errorf("failed to scroll finger on %s: %w", s, err)
 	}
 	return nil
 }
@@ -251,8 +644,69 @@ func (s *Selection) ScrollFinger(xOffset, yOffset int) error {
 func (s *Selection) SendKeys(key string) error {
 	return s.forEachElement(func(selectedElement element.Element) error {
 		if err := selectedElement.Value(key); err != nil {
-			return fmt.Errorf("failed to send key %s on %s: %s", key, s, err)
+			return fmt.Errorf("failed to send key %s on %s: %w", key, s, err)
 		}
 		return nil
 	})
 }
+
+// PressKey sends exactly one key to exactly one element, ex.
+//    searchBox.PressKey(key.Enter)
+// The key may be one of the special keys in the key package or a single
+// character. Use SendKeys or Fill to send several keys at once.
+func (s *Selection) PressKey(key string) error {
+	if utf8.RuneCountInString(key) != 1 {
+		return fmt.Errorf("failed to press key on '%s': expected exactly one key, got %q", s.selectors, key)
+	}
+
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return fmt.Errorf("failed to press key on '%s': %w", s.selectors, err)
+	}
+
+	if err := selectedElement.Value(key); err != nil {
+		return fmt.Errorf("failed to press key on '%s': %w", s.selectors, err)
+	}
+	return nil
+}
+
+const pasteScript = `
+var element = arguments[0], text = arguments[1];
+element.focus();
+var data = new DataTransfer();
+data.setData("text/plain", text);
+var event = new ClipboardEvent("paste", {clipboardData: data, bubbles: true, cancelable: true});
+if (!element.dispatchEvent(event)) {
+	return;
+}
+if (typeof element.setRangeText === "function") {
+	element.setRangeText(text, element.selectionStart, element.selectionEnd, "end");
+} else {
+	document.execCommand("insertText", false, text);
+}
+element.dispatchEvent(new Event("input", {bubbles: true}));`
+
+// Paste simulates pasting the provided text into exactly one element. A paste
+// event carrying the text is dispatched to the element, and unless a listener
+// cancels the event, the text is inserted at the cursor and an input event is
+// dispatched.
+//
+// The system clipboard is never read or modified, because WebDrivers do not
+// provide access to it and browsers only allow scripts to write to it in
+// response to user gestures. As a result, Paste cannot trigger native paste
+// behavior, and listeners that read from navigator.clipboard instead of the
+// event's clipboardData will not see the text. Older browsers that cannot
+// construct ClipboardEvent or DataTransfer objects (ex. PhantomJS) are not
+// supported.
+func (s *Selection) Paste(text string) error {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return fmt.Errorf("failed to paste into '%s': %w", s.selectors, err)
+	}
+
+	arguments := []interface{}{elementArgument(selectedElement), text}
+	if err := s.session.Execute(pasteScript, arguments, nil); err != nil {
+		return fmt.Errorf("failed to paste into '%s': %w", s.selectors, err)
+	}
+	return nil
+}
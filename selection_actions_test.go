@@ -2,7 +2,11 @@ package agouti_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -11,6 +15,7 @@ import (
 	"github.com/sclevine/agouti/internal/element"
 	. "github.com/sclevine/agouti/internal/matchers"
 	"github.com/sclevine/agouti/internal/mocks"
+	"github.com/sclevine/agouti/key"
 )
 
 var _ = Describe("Selection Actions", func() {
@@ -28,6 +33,7 @@ var _ = Describe("Selection Actions", func() {
 		secondElement = &mocks.Element{}
 		elementRepository = &mocks.ElementRepository{}
 		selection = NewTestMultiSelection(session, elementRepository, "#selector")
+		UseFakeClock(selection)
 		elementRepository.GetAtLeastOneCall.ReturnElements = []element.Element{firstElement, secondElement}
 	})
 
@@ -93,6 +99,324 @@ var _ = Describe("Selection Actions", func() {
 				Expect(selection.DoubleClick()).To(MatchError("failed to double-click on selection 'CSS: #selector': some error"))
 			})
 		})
+
+		Context("when a stability timeout is set", func() {
+			var moving *movingSession
+
+			BeforeEach(func() {
+				moving = &movingSession{Session: session}
+				selection = WithStabilityTimeout(NewTestMultiSelection(moving, elementRepository, "#selector"), time.Second)
+			})
+
+			It("should wait until the element stops moving before double-clicking", func() {
+				moving.movingReads = 3
+				Expect(selection.DoubleClick()).To(Succeed())
+				Expect(moving.reads).To(BeNumerically(">=", 5))
+				Expect(session.ExecuteCall.Body).To(ContainSubstring("getBoundingClientRect()"))
+				Expect(session.DoubleClickCall.Called).To(BeTrue())
+			})
+
+			Context("when the element does not stop moving before the timeout", func() {
+				It("should return an error without double-clicking", func() {
+					moving.movingReads = 1000
+					selection = WithStabilityTimeout(NewTestMultiSelection(moving, elementRepository, "#selector"), 20*time.Millisecond)
+					err := selection.DoubleClick()
+					Expect(err).To(MatchError("failed to double-click on selection 'CSS: #selector': element did not stop moving within 20ms"))
+					Expect(session.DoubleClickCall.Called).To(BeFalse())
+				})
+			})
+
+			Context("when reading the element position fails", func() {
+				It("should return an error", func() {
+					session.ExecuteCall.Err = errors.New("some error")
+					Expect(selection.DoubleClick()).To(MatchError("failed to double-click on selection 'CSS: #selector': some error"))
+				})
+			})
+		})
+
+		Context("when the WebDriver uses the W3C protocol", func() {
+			BeforeEach(func() {
+				session.ProtocolCall.ReturnProtocol = "w3c"
+			})
+
+			It("should successfully double-click on each element using pointer actions", func() {
+				Expect(selection.DoubleClick()).To(Succeed())
+				Expect(session.PointerDoubleClickCall.Element).To(ExactlyEqual(apiElement))
+			})
+
+			It("should not use the legacy mouse commands", func() {
+				Expect(selection.DoubleClick()).To(Succeed())
+				Expect(session.MoveToCall.Element).To(BeNil())
+				Expect(session.DoubleClickCall.Called).To(BeFalse())
+			})
+
+			Context("when zero elements are returned", func() {
+				It("should return an error", func() {
+					elementRepository.GetAtLeastOneCall.Err = errors.New("some error")
+					Expect(selection.DoubleClick()).To(MatchError("failed to select elements from selection 'CSS: #selector': some error"))
+				})
+			})
+
+			Context("when the pointer actions fail", func() {
+				It("should return an error", func() {
+					session.PointerDoubleClickCall.Err = errors.New("some error")
+					Expect(selection.DoubleClick()).To(MatchError("failed to double-click on selection 'CSS: #selector': some error"))
+				})
+			})
+		})
+	})
+
+	Describe("#ClickAndWaitForNavigation", func() {
+		var navigatingElement *navigatingElement
+
+		BeforeEach(func() {
+			session.GetURLCall.ReturnURL = "http://example.com/start"
+			navigatingElement = newNavigatingElement(firstElement, session)
+			elementRepository.GetExactlyOneCall.ReturnElement = navigatingElement
+		})
+
+		It("should mark the current document and click on the element", func() {
+			navigatingElement.newURL = "http://example.com/next"
+			Expect(selection.ClickAndWaitForNavigation(time.Second)).To(Succeed())
+			Expect(navigatingElement.markedBody).To(Equal("window.__agoutiNavigationMarker = true;"))
+			Expect(firstElement.ClickCall.Called).To(BeTrue())
+		})
+
+		Context("when the URL changes after the click", func() {
+			It("should successfully return", func() {
+				navigatingElement.newURL = "http://example.com/next"
+				Expect(selection.ClickAndWaitForNavigation(time.Second)).To(Succeed())
+			})
+		})
+
+		Context("when the document is reloaded without changing the URL", func() {
+			It("should successfully return", func() {
+				session.ExecuteCall.Result = "true"
+				Expect(selection.ClickAndWaitForNavigation(time.Second)).To(Succeed())
+				Expect(session.ExecuteCall.Body).To(ContainSubstring("!window.__agoutiNavigationMarker"))
+			})
+		})
+
+		Context("when no navigation occurs before the timeout", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Result = "false"
+				err := selection.ClickAndWaitForNavigation(20 * time.Millisecond)
+				Expect(err).To(MatchError("clicked on selection 'CSS: #selector' but no navigation occurred within 20ms"))
+			})
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				err := selection.ClickAndWaitForNavigation(time.Second)
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when retrieving the starting URL fails", func() {
+			It("should return an error", func() {
+				session.GetURLCall.Err = errors.New("some error")
+				err := selection.ClickAndWaitForNavigation(time.Second)
+				Expect(err).To(MatchError("failed to retrieve URL: some error"))
+				Expect(firstElement.ClickCall.Called).To(BeFalse())
+			})
+		})
+
+		Context("when marking the current document fails", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				err := selection.ClickAndWaitForNavigation(time.Second)
+				Expect(err).To(MatchError("failed to mark current document: some error"))
+				Expect(firstElement.ClickCall.Called).To(BeFalse())
+			})
+		})
+
+		Context("when clicking on the element fails", func() {
+			It("should return an error", func() {
+				firstElement.ClickCall.Err = errors.New("some error")
+				err := selection.ClickAndWaitForNavigation(time.Second)
+				Expect(err).To(MatchError("failed to click on selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#Highlight", func() {
+		BeforeEach(func() {
+			firstElement.GetIDCall.ReturnText = "some-id"
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully outline the element using JavaScript", func() {
+			Expect(selection.Highlight()).To(Succeed())
+			Expect(session.ExecuteCall.Body).To(ContainSubstring(`element.style.outline = "3px solid #ff00ff";`))
+			Expect(session.ExecuteCall.Body).To(ContainSubstring("element.style.outline = outline;"))
+			Expect(session.ExecuteCall.Arguments).To(Equal([]interface{}{map[string]string{
+				"ELEMENT":                             "some-id",
+				"element-6066-11e4-a52e-4f735466cecf": "some-id",
+			}}))
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				Expect(selection.Highlight()).To(MatchError("failed to select element from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(selection.Highlight()).To(MatchError("failed to highlight selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#RightClick", func() {
+		var apiElement *api.Element
+
+		BeforeEach(func() {
+			apiElement = &api.Element{}
+			elementRepository.GetExactlyOneCall.ReturnElement = apiElement
+		})
+
+		It("should successfully move the mouse to the middle of the selected element", func() {
+			Expect(selection.RightClick()).To(Succeed())
+			Expect(session.MoveToCall.Element).To(ExactlyEqual(apiElement))
+			Expect(session.MoveToCall.Offset).To(BeNil())
+		})
+
+		It("should successfully click the right mouse button", func() {
+			Expect(selection.RightClick()).To(Succeed())
+			Expect(session.ClickCall.Button).To(Equal(api.RightButton))
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				Expect(selection.RightClick()).To(MatchError("failed to right-click 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when moving over the element fails", func() {
+			It("should return an error", func() {
+				session.MoveToCall.Err = errors.New("some error")
+				Expect(selection.RightClick()).To(MatchError("failed to right-click 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when clicking the right mouse button fails", func() {
+			It("should return an error", func() {
+				session.ClickCall.Err = errors.New("some error")
+				Expect(selection.RightClick()).To(MatchError("failed to right-click 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the session uses the W3C protocol", func() {
+			BeforeEach(func() {
+				session.ProtocolCall.ReturnProtocol = "w3c"
+			})
+
+			It("should successfully right-click the selected element using a pointer action", func() {
+				Expect(selection.RightClick()).To(Succeed())
+				Expect(session.PointerClickCall.Element).To(ExactlyEqual(apiElement))
+				Expect(session.PointerClickCall.Button).To(Equal(api.RightButton))
+				Expect(session.MoveToCall.Element).To(BeNil())
+				Expect(session.ClickCall.Button).To(Equal(api.Button(0)))
+			})
+
+			Context("when the pointer action fails", func() {
+				It("should return an error", func() {
+					session.PointerClickCall.Err = errors.New("some error")
+					Expect(selection.RightClick()).To(MatchError("failed to right-click 'CSS: #selector': some error"))
+				})
+			})
+		})
+	})
+
+	Describe("#ClickAtOffset", func() {
+		var apiElement *api.Element
+
+		BeforeEach(func() {
+			apiElement = &api.Element{}
+			elementRepository.GetExactlyOneCall.ReturnElement = apiElement
+		})
+
+		It("should successfully move the mouse to the offset within the selected element", func() {
+			Expect(selection.ClickAtOffset(10, 20)).To(Succeed())
+			Expect(session.MoveToCall.Element).To(ExactlyEqual(apiElement))
+			Expect(session.MoveToCall.Offset).To(Equal(api.XYOffset{X: 10, Y: 20}))
+		})
+
+		It("should successfully click the left mouse button", func() {
+			Expect(selection.ClickAtOffset(10, 20)).To(Succeed())
+			Expect(session.ClickCall.Button).To(Equal(api.LeftButton))
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				Expect(selection.ClickAtOffset(10, 20)).To(MatchError("failed to click at offset (10, 20) within 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when moving to the offset fails", func() {
+			It("should return an error", func() {
+				session.MoveToCall.Err = errors.New("some error")
+				Expect(selection.ClickAtOffset(10, 20)).To(MatchError("failed to click at offset (10, 20) within 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when clicking the left mouse button fails", func() {
+			It("should return an error", func() {
+				session.ClickCall.Err = errors.New("some error")
+				Expect(selection.ClickAtOffset(10, 20)).To(MatchError("failed to click at offset (10, 20) within 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#HoverAndGetText", func() {
+		var (
+			apiElement *api.Element
+			bus        *tooltipBus
+		)
+
+		BeforeEach(func() {
+			apiElement = &api.Element{}
+			elementRepository.GetExactlyOneCall.ReturnElement = apiElement
+			bus = &tooltipBus{Displayed: true, Text: "some tooltip"}
+			tooltip := &api.Element{Session: &api.Session{Bus: bus}}
+			session.GetElementsCall.ReturnElements = []*api.Element{tooltip}
+		})
+
+		It("should move the mouse to the selected element and return the tooltip text", func() {
+			Expect(selection.HoverAndGetText(".tooltip", time.Second)).To(Equal("some tooltip"))
+			Expect(session.MoveToCall.Element).To(ExactlyEqual(apiElement))
+			Expect(session.GetElementsCall.Selector).To(Equal(api.Selector{Using: "css selector", Value: ".tooltip"}))
+		})
+
+		Context("when the tooltip does not become visible before the timeout", func() {
+			It("should return an error", func() {
+				bus.Displayed = false
+				_, err := selection.HoverAndGetText(".tooltip", 20*time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for tooltip '.tooltip' after hovering over 'CSS: #selector'"))
+			})
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.HoverAndGetText(".tooltip", time.Second)
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when moving the mouse fails", func() {
+			It("should return an error", func() {
+				session.MoveToCall.Err = errors.New("some error")
+				_, err := selection.HoverAndGetText(".tooltip", time.Second)
+				Expect(err).To(MatchError("failed to move mouse to selection 'CSS: #selector': some error"))
+			})
+		})
 	})
 
 	Describe("#Fill", func() {
@@ -152,6 +476,252 @@ var _ = Describe("Selection Actions", func() {
 		})
 	})
 
+	Describe("#ForceClear", func() {
+		It("should successfully select all text and delete it in each element", func() {
+			Expect(selection.ForceClear()).To(Succeed())
+			Expect(firstElement.ValueCall.Text).To(Equal(key.Control + "a" + key.Null + key.Delete))
+			Expect(secondElement.ValueCall.Text).To(Equal(key.Control + "a" + key.Null + key.Delete))
+		})
+
+		Context("when the session platform is macOS", func() {
+			It("should select all text using the command key", func() {
+				session.CapabilitiesCall.ReturnCapabilities = map[string]interface{}{"platformName": "mac"}
+				Expect(selection.ForceClear()).To(Succeed())
+				Expect(firstElement.ValueCall.Text).To(Equal(key.Command + "a" + key.Null + key.Delete))
+			})
+		})
+
+		Context("when zero elements are returned", func() {
+			It("should return an error", func() {
+				elementRepository.GetAtLeastOneCall.Err = errors.New("some error")
+				Expect(selection.ForceClear()).To(MatchError("failed to select elements from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when clearing any element fails", func() {
+			It("should return an error", func() {
+				secondElement.ValueCall.Err = errors.New("some error")
+				Expect(selection.ForceClear()).To(MatchError("failed to force clear selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#FillAndVerify", func() {
+		BeforeEach(func() {
+			firstElement.GetPropertyCall.ReturnValue = "some text"
+			secondElement.GetPropertyCall.ReturnValue = "some text"
+		})
+
+		It("should fill each element and verify its value", func() {
+			Expect(selection.FillAndVerify("some text")).To(Succeed())
+			Expect(firstElement.ClearCall.Called).To(BeTrue())
+			Expect(firstElement.ValueCall.Text).To(Equal("some text"))
+			Expect(secondElement.ValueCall.Text).To(Equal("some text"))
+			Expect(secondElement.GetPropertyCall.Property).To(Equal("value"))
+		})
+
+		Context("when the value of any element does not match the text", func() {
+			It("should return an error", func() {
+				secondElement.GetPropertyCall.ReturnValue = "some tex"
+				Expect(selection.FillAndVerify("some text")).To(MatchError("failed to fill selection 'CSS: #selector': value mismatch after fill: expected 'some text' got 'some tex'"))
+			})
+		})
+
+		Context("when zero elements are returned", func() {
+			It("should return an error", func() {
+				elementRepository.GetAtLeastOneCall.Err = errors.New("some error")
+				Expect(selection.FillAndVerify("some text")).To(MatchError("failed to select elements from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when clearing any element fails", func() {
+			It("should return an error", func() {
+				secondElement.ClearCall.Err = errors.New("some error")
+				Expect(selection.FillAndVerify("some text")).To(MatchError("failed to clear selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when entering text into any element fails", func() {
+			It("should return an error", func() {
+				secondElement.ValueCall.Err = errors.New("some error")
+				Expect(selection.FillAndVerify("some text")).To(MatchError("failed to enter text into selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when reading the value of any element fails", func() {
+			It("should return an error", func() {
+				secondElement.GetPropertyCall.Err = errors.New("some error")
+				Expect(selection.FillAndVerify("some text")).To(MatchError("failed to read value of selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#FillAndVerifyFunc", func() {
+		var normalize func(expected, actual string) bool
+
+		BeforeEach(func() {
+			firstElement.GetPropertyCall.ReturnValue = "555-1234"
+			secondElement.GetPropertyCall.ReturnValue = "555-1234"
+			normalize = func(expected, actual string) bool {
+				return strings.Replace(actual, "-", "", -1) == expected
+			}
+		})
+
+		It("should fill each element and verify its value using the provided function", func() {
+			Expect(selection.FillAndVerifyFunc("5551234", normalize)).To(Succeed())
+			Expect(firstElement.ValueCall.Text).To(Equal("5551234"))
+			Expect(secondElement.ValueCall.Text).To(Equal("5551234"))
+		})
+
+		Context("when the provided function rejects the value of any element", func() {
+			It("should return an error", func() {
+				secondElement.GetPropertyCall.ReturnValue = "555-123"
+				Expect(selection.FillAndVerifyFunc("5551234", normalize)).To(MatchError("failed to fill selection 'CSS: #selector': value mismatch after fill: expected '5551234' got '555-123'"))
+			})
+		})
+	})
+
+	Describe("#FillReact", func() {
+		BeforeEach(func() {
+			secondElement.GetIDCall.ReturnText = "some-id"
+		})
+
+		It("should set the value of each element with the native setter and dispatch input and change events", func() {
+			Expect(selection.FillReact("some text")).To(Succeed())
+			Expect(session.ExecuteCall.Body).To(ContainSubstring(`Object.getOwnPropertyDescriptor(prototype, "value").set.call(element, text);`))
+			Expect(session.ExecuteCall.Body).To(ContainSubstring(`element.dispatchEvent(new Event("input", {bubbles: true}));`))
+			Expect(session.ExecuteCall.Body).To(ContainSubstring(`element.dispatchEvent(new Event("change", {bubbles: true}));`))
+			Expect(session.ExecuteCall.Arguments).To(Equal([]interface{}{
+				map[string]string{
+					"ELEMENT":                             "some-id",
+					"element-6066-11e4-a52e-4f735466cecf": "some-id",
+				},
+				"some text",
+			}))
+		})
+
+		Context("when zero elements are returned", func() {
+			It("should return an error", func() {
+				elementRepository.GetAtLeastOneCall.Err = errors.New("some error")
+				Expect(selection.FillReact("some text")).To(MatchError("failed to select elements from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(selection.FillReact("some text")).To(MatchError("failed to enter text into selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#FillAndSubmit", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully clear and fill the element with the provided text", func() {
+			Expect(selection.FillAndSubmit("some text")).To(Succeed())
+			Expect(firstElement.ClearCall.Called).To(BeTrue())
+			Expect(firstElement.ValueCall.Text).To(Equal("some text"))
+		})
+
+		It("should successfully submit the element", func() {
+			Expect(selection.FillAndSubmit("some text")).To(Succeed())
+			Expect(firstElement.SubmitCall.Called).To(BeTrue())
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				Expect(selection.FillAndSubmit("some text")).To(MatchError("failed to fill and submit 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when clearing the element fails", func() {
+			It("should return an error", func() {
+				firstElement.ClearCall.Err = errors.New("some error")
+				Expect(selection.FillAndSubmit("some text")).To(MatchError("failed to fill and submit 'CSS: #selector': some error"))
+				Expect(firstElement.SubmitCall.Called).To(BeFalse())
+			})
+		})
+
+		Context("when entering text into the element fails", func() {
+			It("should return an error", func() {
+				firstElement.ValueCall.Err = errors.New("some error")
+				Expect(selection.FillAndSubmit("some text")).To(MatchError("failed to fill and submit 'CSS: #selector': some error"))
+				Expect(firstElement.SubmitCall.Called).To(BeFalse())
+			})
+		})
+
+		Context("when submitting the element fails", func() {
+			It("should return an error", func() {
+				firstElement.SubmitCall.Err = errors.New("some error")
+				Expect(selection.FillAndSubmit("some text")).To(MatchError("failed to fill and submit 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#DropFile", func() {
+		var dir, filename string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "agouti")
+			Expect(err).NotTo(HaveOccurred())
+			filename = filepath.Join(dir, "report.json")
+			Expect(ioutil.WriteFile(filename, []byte("some content"), 0644)).To(Succeed())
+			firstElement.GetIDCall.ReturnText = "some-id"
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should dispatch drag events carrying the file contents to the element", func() {
+			Expect(selection.DropFile(filename)).To(Succeed())
+			Expect(session.ExecuteCall.Body).To(ContainSubstring(`["dragenter", "dragover", "drop"]`))
+			Expect(session.ExecuteCall.Arguments).To(Equal([]interface{}{
+				map[string]string{
+					"ELEMENT":                             "some-id",
+					"element-6066-11e4-a52e-4f735466cecf": "some-id",
+				},
+				"report.json",
+				"application/json",
+				"c29tZSBjb250ZW50",
+			}))
+		})
+
+		Context("when the file does not exist", func() {
+			It("should return an error without contacting the session", func() {
+				err := selection.DropFile(filepath.Join(dir, "missing.txt"))
+				Expect(err.Error()).To(HavePrefix("failed to drop file onto 'CSS: #selector': stat "))
+				Expect(session.ExecuteCall.Body).To(BeEmpty())
+			})
+		})
+
+		Context("when the path is a directory", func() {
+			It("should return an error", func() {
+				Expect(selection.DropFile(dir)).To(MatchError("failed to drop file onto 'CSS: #selector': " + dir + " is a directory"))
+			})
+		})
+
+		Context("when the selection does not refer to exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				Expect(selection.DropFile(filename)).To(MatchError("failed to drop file onto 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(selection.DropFile(filename)).To(MatchError("failed to drop file onto 'CSS: #selector': some error"))
+			})
+		})
+	})
+
 	Describe("#UploadFile", func() {
 		BeforeEach(func() {
 			firstElement.GetAttributeCall.ReturnValue = "file"
@@ -384,6 +954,53 @@ var _ = Describe("Selection Actions", func() {
 				Expect(selection.Submit()).To(MatchError("failed to submit selection 'CSS: #selector': some error"))
 			})
 		})
+
+		Context("when the WebDriver uses the W3C protocol", func() {
+			BeforeEach(func() {
+				session.ProtocolCall.ReturnProtocol = "w3c"
+				secondElement.GetIDCall.ReturnText = "some-id"
+			})
+
+			It("should submit the enclosing form of each element using JavaScript", func() {
+				session.ExecuteCall.Result = "true"
+				Expect(selection.Submit()).To(Succeed())
+				Expect(session.ExecuteCall.Body).To(ContainSubstring("form.requestSubmit();"))
+				Expect(session.ExecuteCall.Arguments).To(Equal([]interface{}{
+					map[string]string{
+						"ELEMENT":                             "some-id",
+						"element-6066-11e4-a52e-4f735466cecf": "some-id",
+					},
+				}))
+				Expect(firstElement.SubmitCall.Called).To(BeFalse())
+				Expect(secondElement.SubmitCall.Called).To(BeFalse())
+				Expect(secondElement.ValueCall.Text).To(BeEmpty())
+			})
+
+			Context("when an element is not within a form", func() {
+				It("should send Enter to the element", func() {
+					session.ExecuteCall.Result = "false"
+					Expect(selection.Submit()).To(Succeed())
+					Expect(firstElement.ValueCall.Text).To(Equal(key.Enter))
+					Expect(secondElement.ValueCall.Text).To(Equal(key.Enter))
+					Expect(secondElement.SubmitCall.Called).To(BeFalse())
+				})
+
+				Context("when sending Enter fails", func() {
+					It("should return an error", func() {
+						session.ExecuteCall.Result = "false"
+						secondElement.ValueCall.Err = errors.New("some error")
+						Expect(selection.Submit()).To(MatchError("failed to submit selection 'CSS: #selector': some error"))
+					})
+				})
+			})
+
+			Context("when the script fails", func() {
+				It("should return an error", func() {
+					session.ExecuteCall.Err = errors.New("some error")
+					Expect(selection.Submit()).To(MatchError("failed to submit selection 'CSS: #selector': some error"))
+				})
+			})
+		})
 	})
 
 	// TODO: implement call tracking in mocks
@@ -536,4 +1153,133 @@ var _ = Describe("Selection Actions", func() {
 			})
 		})
 	})
+
+	Describe("#PressKey", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully send the key to the selected element", func() {
+			Expect(selection.PressKey(key.Enter)).To(Succeed())
+			Expect(firstElement.ValueCall.Text).To(Equal(key.Enter))
+		})
+
+		It("should accept a single character", func() {
+			Expect(selection.PressKey("é")).To(Succeed())
+			Expect(firstElement.ValueCall.Text).To(Equal("é"))
+		})
+
+		Context("when more than one key is provided", func() {
+			It("should return an error without sending any keys", func() {
+				Expect(selection.PressKey("ab")).To(MatchError(`failed to press key on 'CSS: #selector': expected exactly one key, got "ab"`))
+				Expect(firstElement.ValueCall.Text).To(BeEmpty())
+			})
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				Expect(selection.PressKey(key.Escape)).To(MatchError("failed to press key on 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when sending the key fails", func() {
+			It("should return an error", func() {
+				firstElement.ValueCall.Err = errors.New("some error")
+				Expect(selection.PressKey(key.Escape)).To(MatchError("failed to press key on 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#Paste", func() {
+		BeforeEach(func() {
+			firstElement.GetIDCall.ReturnText = "some-id"
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should dispatch a paste event carrying the text to the element", func() {
+			Expect(selection.Paste("some text")).To(Succeed())
+			Expect(session.ExecuteCall.Body).To(ContainSubstring(`new ClipboardEvent("paste", {clipboardData: data, bubbles: true, cancelable: true});`))
+			Expect(session.ExecuteCall.Arguments).To(Equal([]interface{}{
+				map[string]string{
+					"ELEMENT":                             "some-id",
+					"element-6066-11e4-a52e-4f735466cecf": "some-id",
+				},
+				"some text",
+			}))
+		})
+
+		Context("when the selection does not refer to exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				Expect(selection.Paste("some text")).To(MatchError("failed to paste into 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				Expect(selection.Paste("some text")).To(MatchError("failed to paste into 'CSS: #selector': some error"))
+			})
+		})
+	})
 })
+
+type navigatingElement struct {
+	*mocks.Element
+	session    *mocks.Session
+	newURL     string
+	markedBody string
+}
+
+func newNavigatingElement(element *mocks.Element, session *mocks.Session) *navigatingElement {
+	return &navigatingElement{Element: element, session: session}
+}
+
+func (e *navigatingElement) Click() error {
+	e.markedBody = e.session.ExecuteCall.Body
+	if err := e.Element.Click(); err != nil {
+		return err
+	}
+	if e.newURL != "" {
+		e.session.GetURLCall.ReturnURL = e.newURL
+	}
+	return nil
+}
+
+// movingSession reports a different element position for the first
+// movingReads reads of the element position, and then a fixed position.
+type movingSession struct {
+	*mocks.Session
+	movingReads int
+	reads       int
+}
+
+func (s *movingSession) Execute(body string, arguments []interface{}, result interface{}) error {
+	if err := s.Session.Execute(body, arguments, result); err != nil {
+		return err
+	}
+	if s.reads < s.movingReads {
+		*result.(*[]float64) = []float64{float64(s.reads), 0, 10, 10}
+	} else {
+		*result.(*[]float64) = []float64{0, 0, 10, 10}
+	}
+	s.reads++
+	return nil
+}
+
+// tooltipBus reports whether a tooltip element is displayed and its text.
+type tooltipBus struct {
+	Displayed bool
+	Text      string
+}
+
+func (b *tooltipBus) Send(method, endpoint string, body, result interface{}) error {
+	switch {
+	case strings.HasSuffix(endpoint, "/displayed"):
+		*result.(*bool) = b.Displayed
+	case strings.HasSuffix(endpoint, "/text"):
+		*result.(*string) = b.Text
+	}
+	return nil
+}
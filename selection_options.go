@@ -0,0 +1,174 @@
+package agouti
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sclevine/agouti/internal/element"
+	"github.com/sclevine/agouti/internal/target"
+)
+
+// SelectByValue may be called on a selection of any number of <select> elements
+// to select the <option> elements under those <select> elements whose value
+// attribute matches the provided value. Unlike Select, SelectByValue is not
+// affected by localized option text.
+func (s *Selection) SelectByValue(value string) error {
+	return s.forEachElement(func(selectedElement element.Element) error {
+		if err := selectOptionsByValue(selectedElement, value); err != nil {
+			return fmt.Errorf("failed to select option with value '%s' in '%s': %w", value, s.selectors, err)
+		}
+		return nil
+	})
+}
+
+func selectOptionsByValue(selectElement element.Element, value string) error {
+	optionXPath := fmt.Sprintf(`.//option[@value=%s]`, xpathLiteral(value))
+	optionToSelect := target.Selector{Type: target.XPath, Value: optionXPath}
+	options, err := selectElement.GetElements(optionToSelect.API())
+	if err != nil {
+		return err
+	}
+
+	if len(options) == 0 {
+		return errors.New("no matching option")
+	}
+
+	for _, option := range options {
+		if err := option.Click(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SelectByIndex may be called on a selection of any number of <select> elements
+// to select the <option> element at the provided zero-based index under each of
+// those <select> elements. Options within <optgroup> elements are counted in
+// document order.
+func (s *Selection) SelectByIndex(index int) error {
+	return s.forEachElement(func(selectedElement element.Element) error {
+		if err := selectOptionByIndex(selectedElement, index); err != nil {
+			return fmt.Errorf("failed to select option at index %d in '%s': %w", index, s.selectors, err)
+		}
+		return nil
+	})
+}
+
+func selectOptionByIndex(selectElement element.Element, index int) error {
+	allOptions := target.Selector{Type: target.XPath, Value: ".//option"}
+	options, err := selectElement.GetElements(allOptions.API())
+	if err != nil {
+		return err
+	}
+
+	if index < 0 || index >= len(options) {
+		return fmt.Errorf("index out of range (%d options)", len(options))
+	}
+
+	return options[index].Click()
+}
+
+// Deselect may be called on a selection of any number of <select multiple>
+// elements to deselect the currently selected <option> elements under those
+// <select> elements that match the provided text. Options that match but are
+// not selected are left unchanged. An error is returned if any element is not
+// a multiple select or has no matching option.
+func (s *Selection) Deselect(text string) error {
+	return s.forEachElement(func(selectedElement element.Element) error {
+		optionXPath := fmt.Sprintf(`.//option[normalize-space()=%s]`, xpathLiteral(text))
+		if err := deselectOptions(selectedElement, optionXPath, true); err != nil {
+			return fmt.Errorf("failed to deselect option '%s' in '%s': %w", text, s.selectors, err)
+		}
+		return nil
+	})
+}
+
+// DeselectAll may be called on a selection of any number of <select multiple>
+// elements to deselect every selected <option> element under those <select>
+// elements. An error is returned if any element is not a multiple select.
+func (s *Selection) DeselectAll() error {
+	return s.forEachElement(func(selectedElement element.Element) error {
+		if err := deselectOptions(selectedElement, ".//option", false); err != nil {
+			return fmt.Errorf("failed to deselect all options in '%s': %w", s.selectors, err)
+		}
+		return nil
+	})
+}
+
+// deselectOptions clicks each selected option matching the provided XPath.
+// Clicking an option in a multiple select toggles whether it is selected,
+// so only selected options are clicked.
+func deselectOptions(selectElement element.Element, optionXPath string, requireMatch bool) error {
+	multiple, err := selectElement.GetAttribute("multiple")
+	if err != nil {
+		return err
+	}
+	if multiple == "" || multiple == "false" {
+		return errors.New("element is not a multiple select")
+	}
+
+	optionsToDeselect := target.Selector{Type: target.XPath, Value: optionXPath}
+	options, err := selectElement.GetElements(optionsToDeselect.API())
+	if err != nil {
+		return err
+	}
+
+	if requireMatch && len(options) == 0 {
+		return errors.New("no matching option")
+	}
+
+	for _, option := range options {
+		selected, err := option.IsSelected()
+		if err != nil {
+			return err
+		}
+		if !selected {
+			continue
+		}
+		if err := option.Click(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SelectedOptions returns the text of each currently selected <option> element
+// under exactly one <select> element, in document order, including options
+// within <optgroup> elements.
+func (s *Selection) SelectedOptions() ([]string, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return nil, fmt.Errorf("failed to select element from %s: %w", s, err)
+	}
+
+	texts, err := selectedOptionTexts(selectedElement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read selected options of '%s': %w", s.selectors, err)
+	}
+	return texts, nil
+}
+
+func selectedOptionTexts(selectElement element.Element) ([]string, error) {
+	allOptions := target.Selector{Type: target.XPath, Value: ".//option"}
+	options, err := selectElement.GetElements(allOptions.API())
+	if err != nil {
+		return nil, err
+	}
+
+	texts := []string{}
+	for _, option := range options {
+		selected, err := option.IsSelected()
+		if err != nil {
+			return nil, err
+		}
+		if !selected {
+			continue
+		}
+		text, err := option.GetText()
+		if err != nil {
+			return nil, err
+		}
+		texts = append(texts, text)
+	}
+	return texts, nil
+}
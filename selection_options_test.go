@@ -0,0 +1,346 @@
+package agouti_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+	"github.com/sclevine/agouti/api"
+	"github.com/sclevine/agouti/internal/element"
+	"github.com/sclevine/agouti/internal/mocks"
+)
+
+var _ = Describe("Selection Options", func() {
+	var (
+		selection         *MultiSelection
+		session           *mocks.Session
+		elementRepository *mocks.ElementRepository
+		firstElement      *mocks.Element
+		secondElement     *mocks.Element
+		firstOptionBuses  []*mocks.Bus
+		secondOptionBuses []*mocks.Bus
+	)
+
+	BeforeEach(func() {
+		session = &mocks.Session{}
+		firstElement = &mocks.Element{}
+		secondElement = &mocks.Element{}
+		elementRepository = &mocks.ElementRepository{}
+		selection = NewTestMultiSelection(session, elementRepository, "#country")
+		elementRepository.GetAtLeastOneCall.ReturnElements = []element.Element{firstElement, secondElement}
+
+		firstOptionBuses = []*mocks.Bus{{}, {}}
+		secondOptionBuses = []*mocks.Bus{{}, {}}
+		firstElement.GetElementsCall.ReturnElements = []*api.Element{
+			{ID: "one", Session: &api.Session{Bus: firstOptionBuses[0]}},
+			{ID: "two", Session: &api.Session{Bus: firstOptionBuses[1]}},
+		}
+		secondElement.GetElementsCall.ReturnElements = []*api.Element{
+			{ID: "three", Session: &api.Session{Bus: secondOptionBuses[0]}},
+			{ID: "four", Session: &api.Session{Bus: secondOptionBuses[1]}},
+		}
+	})
+
+	Describe("#SelectByValue", func() {
+		It("should successfully retrieve the options with a matching value for each selected element", func() {
+			Expect(selection.SelectByValue("fr")).To(Succeed())
+			Expect(firstElement.GetElementsCall.Selector).To(Equal(api.Selector{Using: "xpath", Value: `.//option[@value="fr"]`}))
+			Expect(secondElement.GetElementsCall.Selector).To(Equal(api.Selector{Using: "xpath", Value: `.//option[@value="fr"]`}))
+		})
+
+		It("should quote a value containing double quotes", func() {
+			Expect(selection.SelectByValue(`12" pizza`)).To(Succeed())
+			Expect(firstElement.GetElementsCall.Selector).To(Equal(api.Selector{Using: "xpath", Value: `.//option[@value='12" pizza']`}))
+		})
+
+		It("should successfully click on all options with a matching value", func() {
+			Expect(selection.SelectByValue("fr")).To(Succeed())
+			Expect(firstOptionBuses[0].SendCall.Endpoint).To(Equal("element/one/click"))
+			Expect(firstOptionBuses[1].SendCall.Endpoint).To(Equal("element/two/click"))
+			Expect(secondOptionBuses[0].SendCall.Endpoint).To(Equal("element/three/click"))
+			Expect(secondOptionBuses[1].SendCall.Endpoint).To(Equal("element/four/click"))
+		})
+
+		Context("when zero elements are returned", func() {
+			It("should return an error", func() {
+				elementRepository.GetAtLeastOneCall.Err = errors.New("some error")
+				Expect(selection.SelectByValue("fr")).To(MatchError("failed to select elements from selection 'CSS: #country': some error"))
+			})
+		})
+
+		Context("when the options cannot be retrieved", func() {
+			It("should return an error", func() {
+				secondElement.GetElementsCall.Err = errors.New("some error")
+				Expect(selection.SelectByValue("fr")).To(MatchError("failed to select option with value 'fr' in 'CSS: #country': some error"))
+			})
+		})
+
+		Context("when no option has a matching value", func() {
+			It("should return an error", func() {
+				secondElement.GetElementsCall.ReturnElements = []*api.Element{}
+				Expect(selection.SelectByValue("fr")).To(MatchError("failed to select option with value 'fr' in 'CSS: #country': no matching option"))
+			})
+		})
+
+		Context("when clicking an option fails", func() {
+			It("should return an error", func() {
+				secondOptionBuses[1].SendCall.Err = errors.New("some error")
+				Expect(selection.SelectByValue("fr")).To(MatchError("failed to select option with value 'fr' in 'CSS: #country': some error"))
+			})
+		})
+	})
+
+	Describe("#SelectByIndex", func() {
+		It("should successfully retrieve all options, including those in option groups, for each selected element", func() {
+			Expect(selection.SelectByIndex(1)).To(Succeed())
+			Expect(firstElement.GetElementsCall.Selector).To(Equal(api.Selector{Using: "xpath", Value: ".//option"}))
+			Expect(secondElement.GetElementsCall.Selector).To(Equal(api.Selector{Using: "xpath", Value: ".//option"}))
+		})
+
+		It("should successfully click only on the option at the provided index", func() {
+			Expect(selection.SelectByIndex(1)).To(Succeed())
+			Expect(firstOptionBuses[0].SendCall.Endpoint).To(BeEmpty())
+			Expect(firstOptionBuses[1].SendCall.Endpoint).To(Equal("element/two/click"))
+			Expect(secondOptionBuses[0].SendCall.Endpoint).To(BeEmpty())
+			Expect(secondOptionBuses[1].SendCall.Endpoint).To(Equal("element/four/click"))
+		})
+
+		Context("when zero elements are returned", func() {
+			It("should return an error", func() {
+				elementRepository.GetAtLeastOneCall.Err = errors.New("some error")
+				Expect(selection.SelectByIndex(1)).To(MatchError("failed to select elements from selection 'CSS: #country': some error"))
+			})
+		})
+
+		Context("when the options cannot be retrieved", func() {
+			It("should return an error", func() {
+				secondElement.GetElementsCall.Err = errors.New("some error")
+				Expect(selection.SelectByIndex(1)).To(MatchError("failed to select option at index 1 in 'CSS: #country': some error"))
+			})
+		})
+
+		Context("when the index is out of range", func() {
+			It("should return an error", func() {
+				Expect(selection.SelectByIndex(3)).To(MatchError("failed to select option at index 3 in 'CSS: #country': index out of range (2 options)"))
+				Expect(selection.SelectByIndex(-1)).To(MatchError("failed to select option at index -1 in 'CSS: #country': index out of range (2 options)"))
+			})
+		})
+
+		Context("when clicking on the option fails", func() {
+			It("should return an error", func() {
+				firstOptionBuses[1].SendCall.Err = errors.New("some error")
+				Expect(selection.SelectByIndex(1)).To(MatchError("failed to select option at index 1 in 'CSS: #country': some error"))
+			})
+		})
+	})
+
+	Describe("#Deselect", func() {
+		BeforeEach(func() {
+			selection = NewTestMultiSelection(session, elementRepository, "#colors")
+			firstElement.GetAttributeCall.ReturnValue = "true"
+			secondElement.GetAttributeCall.ReturnValue = "true"
+			firstOptionBuses[0].SendCall.Result = "true"
+			firstOptionBuses[1].SendCall.Result = "false"
+			secondOptionBuses[0].SendCall.Result = "true"
+			secondOptionBuses[1].SendCall.Result = "true"
+		})
+
+		It("should verify that each selected element is a multiple select", func() {
+			Expect(selection.Deselect("Red")).To(Succeed())
+			Expect(firstElement.GetAttributeCall.Attribute).To(Equal("multiple"))
+			Expect(secondElement.GetAttributeCall.Attribute).To(Equal("multiple"))
+		})
+
+		It("should successfully retrieve the options with matching text for each selected element", func() {
+			Expect(selection.Deselect("Red")).To(Succeed())
+			Expect(firstElement.GetElementsCall.Selector).To(Equal(api.Selector{Using: "xpath", Value: `.//option[normalize-space()="Red"]`}))
+			Expect(secondElement.GetElementsCall.Selector).To(Equal(api.Selector{Using: "xpath", Value: `.//option[normalize-space()="Red"]`}))
+		})
+
+		It("should quote text containing double quotes", func() {
+			Expect(selection.Deselect(`"Red"`)).To(Succeed())
+			Expect(firstElement.GetElementsCall.Selector).To(Equal(api.Selector{Using: "xpath", Value: `.//option[normalize-space()='"Red"']`}))
+		})
+
+		It("should successfully click only on matching options that are selected", func() {
+			Expect(selection.Deselect("Red")).To(Succeed())
+			Expect(firstOptionBuses[0].SendCall.Endpoint).To(Equal("element/one/click"))
+			Expect(firstOptionBuses[1].SendCall.Endpoint).To(Equal("element/two/selected"))
+			Expect(secondOptionBuses[0].SendCall.Endpoint).To(Equal("element/three/click"))
+			Expect(secondOptionBuses[1].SendCall.Endpoint).To(Equal("element/four/click"))
+		})
+
+		Context("when zero elements are returned", func() {
+			It("should return an error", func() {
+				elementRepository.GetAtLeastOneCall.Err = errors.New("some error")
+				Expect(selection.Deselect("Red")).To(MatchError("failed to select elements from selection 'CSS: #colors': some error"))
+			})
+		})
+
+		Context("when an element is not a multiple select", func() {
+			It("should return an error without clicking on any of its options", func() {
+				firstElement.GetAttributeCall.ReturnValue = ""
+				Expect(selection.Deselect("Red")).To(MatchError("failed to deselect option 'Red' in 'CSS: #colors': element is not a multiple select"))
+				Expect(firstOptionBuses[0].SendCall.Endpoint).To(BeEmpty())
+			})
+		})
+
+		Context("when determining whether an element is a multiple select fails", func() {
+			It("should return an error", func() {
+				secondElement.GetAttributeCall.Err = errors.New("some error")
+				Expect(selection.Deselect("Red")).To(MatchError("failed to deselect option 'Red' in 'CSS: #colors': some error"))
+			})
+		})
+
+		Context("when the options cannot be retrieved", func() {
+			It("should return an error", func() {
+				secondElement.GetElementsCall.Err = errors.New("some error")
+				Expect(selection.Deselect("Red")).To(MatchError("failed to deselect option 'Red' in 'CSS: #colors': some error"))
+			})
+		})
+
+		Context("when no option has matching text", func() {
+			It("should return an error", func() {
+				secondElement.GetElementsCall.ReturnElements = []*api.Element{}
+				Expect(selection.Deselect("Red")).To(MatchError("failed to deselect option 'Red' in 'CSS: #colors': no matching option"))
+			})
+		})
+
+		Context("when clicking on an option fails", func() {
+			It("should return an error", func() {
+				secondOptionBuses[1].SendCall.Err = errors.New("some error")
+				Expect(selection.Deselect("Red")).To(MatchError("failed to deselect option 'Red' in 'CSS: #colors': some error"))
+			})
+		})
+	})
+
+	Describe("#DeselectAll", func() {
+		BeforeEach(func() {
+			selection = NewTestMultiSelection(session, elementRepository, "#colors")
+			firstElement.GetAttributeCall.ReturnValue = "true"
+			secondElement.GetAttributeCall.ReturnValue = "true"
+			firstOptionBuses[0].SendCall.Result = "false"
+			firstOptionBuses[1].SendCall.Result = "true"
+			secondOptionBuses[0].SendCall.Result = "true"
+			secondOptionBuses[1].SendCall.Result = "false"
+		})
+
+		It("should successfully retrieve all options, including those in option groups, for each selected element", func() {
+			Expect(selection.DeselectAll()).To(Succeed())
+			Expect(firstElement.GetElementsCall.Selector).To(Equal(api.Selector{Using: "xpath", Value: ".//option"}))
+			Expect(secondElement.GetElementsCall.Selector).To(Equal(api.Selector{Using: "xpath", Value: ".//option"}))
+		})
+
+		It("should successfully click only on options that are selected", func() {
+			Expect(selection.DeselectAll()).To(Succeed())
+			Expect(firstOptionBuses[0].SendCall.Endpoint).To(Equal("element/one/selected"))
+			Expect(firstOptionBuses[1].SendCall.Endpoint).To(Equal("element/two/click"))
+			Expect(secondOptionBuses[0].SendCall.Endpoint).To(Equal("element/three/click"))
+			Expect(secondOptionBuses[1].SendCall.Endpoint).To(Equal("element/four/selected"))
+		})
+
+		Context("when there are no options", func() {
+			It("should successfully return", func() {
+				secondElement.GetElementsCall.ReturnElements = []*api.Element{}
+				Expect(selection.DeselectAll()).To(Succeed())
+			})
+		})
+
+		Context("when an element is not a multiple select", func() {
+			It("should return an error", func() {
+				secondElement.GetAttributeCall.ReturnValue = ""
+				Expect(selection.DeselectAll()).To(MatchError("failed to deselect all options in 'CSS: #colors': element is not a multiple select"))
+			})
+		})
+
+		Context("when determining whether an option is selected fails", func() {
+			It("should return an error", func() {
+				firstOptionBuses[0].SendCall.Err = errors.New("some error")
+				Expect(selection.DeselectAll()).To(MatchError("failed to deselect all options in 'CSS: #colors': some error"))
+			})
+		})
+	})
+
+	Describe("#SelectedOptions", func() {
+		var optionBuses []*optionBus
+
+		BeforeEach(func() {
+			selection = NewTestMultiSelection(session, elementRepository, "#colors")
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+			optionBuses = []*optionBus{
+				{Selected: true, Text: "Red"},
+				{Selected: false, Text: "Green"},
+				{Selected: true, Text: "Blue"},
+			}
+			firstElement.GetElementsCall.ReturnElements = []*api.Element{
+				{ID: "one", Session: &api.Session{Bus: optionBuses[0]}},
+				{ID: "two", Session: &api.Session{Bus: optionBuses[1]}},
+				{ID: "three", Session: &api.Session{Bus: optionBuses[2]}},
+			}
+		})
+
+		It("should successfully retrieve all options of the selected element, including those in option groups", func() {
+			_, err := selection.SelectedOptions()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(firstElement.GetElementsCall.Selector).To(Equal(api.Selector{Using: "xpath", Value: ".//option"}))
+		})
+
+		It("should return the text of each selected option in document order", func() {
+			Expect(selection.SelectedOptions()).To(Equal([]string{"Red", "Blue"}))
+		})
+
+		Context("when no options are selected", func() {
+			It("should return an empty slice", func() {
+				optionBuses[0].Selected = false
+				optionBuses[2].Selected = false
+				Expect(selection.SelectedOptions()).To(BeEmpty())
+			})
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.SelectedOptions()
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #colors': some error"))
+			})
+		})
+
+		Context("when the options cannot be retrieved", func() {
+			It("should return an error", func() {
+				firstElement.GetElementsCall.Err = errors.New("some error")
+				_, err := selection.SelectedOptions()
+				Expect(err).To(MatchError("failed to read selected options of 'CSS: #colors': some error"))
+			})
+		})
+
+		Context("when reading an option fails", func() {
+			It("should return an error", func() {
+				optionBuses[2].Err = errors.New("some error")
+				_, err := selection.SelectedOptions()
+				Expect(err).To(MatchError("failed to read selected options of 'CSS: #colors': some error"))
+			})
+		})
+	})
+})
+
+// optionBus responds to the selected and text requests for a single option.
+type optionBus struct {
+	Selected bool
+	Text     string
+	Err      error
+}
+
+func (b *optionBus) Send(method, endpoint string, body, result interface{}) error {
+	if b.Err != nil {
+		return b.Err
+	}
+	switch {
+	case strings.HasSuffix(endpoint, "/selected"):
+		*result.(*bool) = b.Selected
+	case strings.HasSuffix(endpoint, "/text"):
+		*result.(*string) = b.Text
+	}
+	return nil
+}
@@ -2,39 +2,66 @@ package agouti
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/sclevine/agouti/internal/element"
 )
 
-// Text returns the entirety of the text content for exactly one element.
-func (s *Selection) Text() (string, error) {
-	selectedElement, err := s.elements.GetExactlyOne()
-	if err != nil {
-		return "", fmt.Errorf("failed to select element from %s: %s", s, err)
-	}
+type valueMethod func(element element.Element) (string, error)
 
-	text, err := selectedElement.GetText()
+func (s *Selection) getValue(method valueMethod, name string) (string, error) {
+	value, selectErr, err := s.readValue(method)
+	if selectErr != nil {
+		return "", fmt.Errorf("failed to select element from %s: %w", s, selectErr)
+	}
 	if err != nil {
-		return "", fmt.Errorf("failed to retrieve text for %s: %s", s, err)
+		return "", fmt.Errorf("failed to retrieve %s for %s: %w", name, s, err)
 	}
-	return text, nil
+	return value, nil
+}
+
+// readValue reads a value from exactly one selected element, selecting the
+// element again if it goes stale while the value is being read, as many times
+// as the stale element retry setting allows. Failures to select the element
+// are returned separately from failures to read the value.
+func (s *Selection) readValue(method valueMethod) (value string, selectErr, err error) {
+	s.retry(s.staleElementRetries()+1, func() error {
+		var selectedElement element.Element
+		if selectedElement, selectErr = s.elements.GetExactlyOne(); selectErr != nil {
+			return nil
+		}
+		if value, err = method(selectedElement); IsStaleElement(err) {
+			return err
+		}
+		return nil
+	}, nil)
+	return value, selectErr, err
+}
+
+// Text returns the entirety of the text content for exactly one element.
+// If the element goes stale while its text is being read, it is selected
+// again and its text is read once more, or as many times as configured by
+// Page.SetStaleElementRetries.
+func (s *Selection) Text() (string, error) {
+	return s.getValue(element.Element.GetText, "text")
 }
 
 // Active returns true if the single element that the selection refers to is active.
 func (s *Selection) Active() (bool, error) {
 	selectedElement, err := s.elements.GetExactlyOne()
 	if err != nil {
-		return false, fmt.Errorf("failed to select element from %s: %s", s, err)
+		return false, fmt.Errorf("failed to select element from %s: %w", s, err)
 	}
 
 	activeElement, err := s.session.GetActiveElement()
 	if err != nil {
-		return false, fmt.Errorf("failed to retrieve active element: %s", err)
+		return false, fmt.Errorf("failed to retrieve active element: %w", err)
 	}
 
 	equal, err := selectedElement.IsEqualTo(activeElement)
 	if err != nil {
-		return false, fmt.Errorf("failed to compare selection to active element: %s", err)
+		return false, fmt.Errorf("failed to compare selection to active element: %w", err)
 	}
 
 	return equal, nil
@@ -43,40 +70,98 @@ func (s *Selection) Active() (bool, error) {
 type propertyMethod func(element element.Element, property string) (string, error)
 
 func (s *Selection) hasProperty(method propertyMethod, property, name string) (string, error) {
-	selectedElement, err := s.elements.GetExactlyOne()
-	if err != nil {
-		return "", fmt.Errorf("failed to select element from %s: %s", s, err)
-	}
-
-	value, err := method(selectedElement, property)
-	if err != nil {
-		return "", fmt.Errorf("failed to retrieve %s value for %s: %s", name, s, err)
-	}
-	return value, nil
+	return s.getValue(func(selectedElement element.Element) (string, error) {
+		return method(selectedElement, property)
+	}, name+" value")
 }
 
 // Attribute returns an attribute value for exactly one element.
+// Like Text, the value is read again from a freshly selected element if the
+// element goes stale.
 func (s *Selection) Attribute(attribute string) (string, error) {
 	return s.hasProperty(element.Element.GetAttribute, attribute, "attribute")
 }
 
+// AttributesMap returns the values of the provided attributes of exactly one
+// element, keyed by attribute name. The element is only selected once.
+func (s *Selection) AttributesMap(names ...string) (map[string]string, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return nil, fmt.Errorf("failed to select element from %s: %w", s, err)
+	}
+
+	attributes := map[string]string{}
+	for _, name := range names {
+		value, err := selectedElement.GetAttribute(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve attributes for %s: %w", s, err)
+		}
+		attributes[name] = value
+	}
+	return attributes, nil
+}
+
 // CSS returns a CSS style property value for exactly one element.
+// Like Text, the value is read again from a freshly selected element if the
+// element goes stale.
 func (s *Selection) CSS(property string) (string, error) {
 	return s.hasProperty(element.Element.GetCSS, property, "CSS property")
 }
 
+// Value returns the current value of exactly one element, such as a text
+// field, number input, or range slider. Unlike Attribute("value"), Value
+// reads the live "value" property, so it reflects user interaction. Like Text,
+// the value is read again from a freshly selected element if the element goes
+// stale.
+func (s *Selection) Value() (string, error) {
+	value, selectErr, err := s.readValue(func(selectedElement element.Element) (string, error) {
+		return selectedElement.GetProperty("value")
+	})
+	if selectErr != nil {
+		err = selectErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read value of '%s': %w", s.selectors, err)
+	}
+	return value, nil
+}
+
+// HasClass returns true if exactly one element has the provided CSS class
+// among the whitespace-separated classes in its class attribute.
+func (s *Selection) HasClass(class string) (bool, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return false, fmt.Errorf("failed to determine classes for '%s': %w", s.selectors, err)
+	}
+
+	classes, err := selectedElement.GetAttribute("class")
+	if err != nil {
+		return false, fmt.Errorf("failed to determine classes for '%s': %w", s.selectors, err)
+	}
+
+	for _, elementClass := range strings.Fields(classes) {
+		if elementClass == class {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 type stateMethod func(element element.Element) (bool, error)
 
 func (s *Selection) hasState(method stateMethod, name string) (bool, error) {
 	elements, err := s.elements.GetAtLeastOne()
 	if err != nil {
-		return false, fmt.Errorf("failed to select elements from %s: %s", s, err)
+		return false, fmt.Errorf("failed to select elements from %s: %w", s, err)
 	}
+	return s.allHaveState(elements, method, name)
+}
 
+func (s *Selection) allHaveState(elements []element.Element, method stateMethod, name string) (bool, error) {
 	for _, selectedElement := range elements {
 		pass, err := method(selectedElement)
 		if err != nil {
-			return false, fmt.Errorf("failed to determine whether %s is %s: %s", s, name, err)
+			return false, fmt.Errorf("failed to determine whether %s is %s: %w", s, name, err)
 		}
 		if !pass {
 			return false, nil
@@ -92,6 +177,8 @@ func (s *Selection) Selected() (bool, error) {
 }
 
 // Visible returns true if all of the elements that the selection refers to are visible.
+// It returns an error if the selection refers to no elements. To treat a missing
+// element as not visible, use MultiSelection.VisibleOrEmpty instead.
 func (s *Selection) Visible() (bool, error) {
 	return s.hasState(element.Element.IsDisplayed, "visible")
 }
@@ -100,3 +187,266 @@ func (s *Selection) Visible() (bool, error) {
 func (s *Selection) Enabled() (bool, error) {
 	return s.hasState(element.Element.IsEnabled, "enabled")
 }
+
+// ElementState describes whether an element is displayed, enabled, and selected.
+type ElementState struct {
+	Displayed bool `json:"displayed"`
+	Enabled   bool `json:"enabled"`
+	Selected  bool `json:"selected"`
+}
+
+const elementStateScript = `
+var element = arguments[0], style = window.getComputedStyle(element);
+return {
+	displayed: !!(element.offsetWidth || element.offsetHeight || element.getClientRects().length) &&
+		style.visibility !== "hidden" && style.display !== "none",
+	enabled: !element.disabled,
+	selected: !!(element.checked || element.selected)
+};`
+
+// State returns whether exactly one element that the selection refers to is
+// displayed, enabled, and selected using a single JavaScript call. This is
+// faster than calling Visible, Enabled, and Selected separately when the
+// WebDriver is remote, but the JavaScript checks only approximate the checks
+// made by the WebDriver.
+func (s *Selection) State() (ElementState, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return ElementState{}, fmt.Errorf("failed to select element from %s: %w", s, err)
+	}
+
+	var state ElementState
+	arguments := []interface{}{elementArgument(selectedElement)}
+	if err := s.session.Execute(elementStateScript, arguments, &state); err != nil {
+		return ElementState{}, fmt.Errorf("failed to retrieve state of %s: %w", s, err)
+	}
+	return state, nil
+}
+
+const inViewportScript = `
+var rect = arguments[0].getBoundingClientRect(), partial = arguments[1];
+var width = window.innerWidth || document.documentElement.clientWidth;
+var height = window.innerHeight || document.documentElement.clientHeight;
+if (partial) {
+	return rect.bottom > 0 && rect.right > 0 && rect.top < height && rect.left < width;
+}
+return rect.top >= 0 && rect.left >= 0 && rect.bottom <= height && rect.right <= width;`
+
+// InViewport returns true if exactly one element that the selection refers to
+// is within the current viewport, without scrolling. If partial is false, the
+// element must be entirely within the viewport. If partial is true, any part
+// of the element within the viewport counts. InViewport only checks the
+// element position, so use Visible to check that the element is displayed.
+func (s *Selection) InViewport(partial bool) (bool, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return false, fmt.Errorf("failed to determine viewport visibility of '%s': %w", s.selectors, err)
+	}
+
+	var inViewport bool
+	arguments := []interface{}{elementArgument(selectedElement), partial}
+	if err := s.session.Execute(inViewportScript, arguments, &inViewport); err != nil {
+		return false, fmt.Errorf("failed to determine viewport visibility of '%s': %w", s.selectors, err)
+	}
+	return inViewport, nil
+}
+
+const accessibleNameScript = `
+var element = arguments[0];
+var labelledBy = element.getAttribute("aria-labelledby");
+if (labelledBy) {
+	var names = labelledBy.split(/\s+/).map(function(id) {
+		var label = document.getElementById(id);
+		return label ? label.textContent.trim() : "";
+	}).filter(function(name) { return name; });
+	if (names.length) {
+		return names.join(" ");
+	}
+}
+var label = element.getAttribute("aria-label");
+if (label && label.trim()) {
+	return label.trim();
+}
+if (element.labels && element.labels.length) {
+	return Array.prototype.map.call(element.labels, function(l) { return l.textContent.trim(); }).join(" ");
+}
+var text = (element.getAttribute("alt") || element.textContent || element.getAttribute("title") || "").trim();
+return text.replace(/\s+/g, " ");`
+
+// AccessibleName returns the accessible name of exactly one element, which is
+// the name announced by assistive technology such as screen readers.
+//
+// When the WebDriver implements the W3C computed label command (ex. recent
+// versions of chromedriver and geckodriver), the name is computed by the
+// browser. Otherwise, the name is approximated using JavaScript from the
+// element's aria-labelledby, aria-label, associated labels, alt text, text
+// content, or title, in that order. The approximation does not implement the
+// full accessible name computation algorithm, so prefer a WebDriver that
+// supports the computed label command when the result matters.
+func (s *Selection) AccessibleName() (string, error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return "", fmt.Errorf("failed to compute accessible name of '%s': %w", s.selectors, err)
+	}
+
+	name, err := selectedElement.GetComputedLabel()
+	if err == nil {
+		return name, nil
+	}
+	if !isUnsupportedError(err) {
+		return "", fmt.Errorf("failed to compute accessible name of '%s': %w", s.selectors, err)
+	}
+
+	arguments := []interface{}{elementArgument(selectedElement)}
+	if err := s.session.Execute(accessibleNameScript, arguments, &name); err != nil {
+		return "", fmt.Errorf("failed to compute accessible name of '%s': %w", s.selectors, err)
+	}
+	return name, nil
+}
+
+// Rect returns the position and size of exactly one element that the selection
+// refers to. W3C WebDrivers are sent a single rect request, while other
+// WebDrivers are sent separate location and size requests.
+func (s *Selection) Rect() (x, y, width, height int, err error) {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("failed to select element from %s: %w", s, err)
+	}
+
+	if s.session.Protocol() == w3cProtocol {
+		x, y, width, height, err = selectedElement.GetRect()
+		if err != nil {
+			return 0, 0, 0, 0, fmt.Errorf("failed to retrieve rect for %s: %w", s, err)
+		}
+		return x, y, width, height, nil
+	}
+
+	if x, y, err = selectedElement.GetLocation(); err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("failed to retrieve location for %s: %w", s, err)
+	}
+	if width, height, err = selectedElement.GetSize(); err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("failed to retrieve size for %s: %w", s, err)
+	}
+	return x, y, width, height, nil
+}
+
+// WaitUntilClickable waits until all of the elements that the selection refers
+// to are both visible and enabled. Elements that cannot be found yet are treated
+// as not clickable. An error is returned if the timeout elapses first.
+func (s *Selection) WaitUntilClickable(timeout time.Duration) error {
+	clickable := s.waitFor(timeout, func() bool {
+		visible, err := s.Visible()
+		if err != nil || !visible {
+			return false
+		}
+		enabled, err := s.Enabled()
+		return err == nil && enabled
+	})
+
+	if !clickable {
+		return fmt.Errorf("timed out after %s waiting for '%s' to be clickable", timeout, s.selectors)
+	}
+	return nil
+}
+
+// WaitUntilStale selects exactly one element that the selection refers to and
+// waits until that element is detached from the page, such as after an action
+// causes the page to navigate or re-render. The element is selected only once,
+// so a new element that matches the selection does not end the wait. An error
+// is returned if the timeout elapses first.
+func (s *Selection) WaitUntilStale(timeout time.Duration) error {
+	selectedElement, err := s.elements.GetExactlyOne()
+	if err != nil {
+		return fmt.Errorf("failed to select element from %s: %w", s, err)
+	}
+
+	var checkErr error
+	stale := s.waitFor(timeout, func() bool {
+		_, err := selectedElement.GetName()
+		if err != nil && !IsStaleElement(err) {
+			checkErr = err
+			return true
+		}
+		return err != nil
+	})
+
+	if checkErr != nil {
+		return fmt.Errorf("failed to determine whether '%s' is stale: %w", s.selectors, checkErr)
+	}
+	if !stale {
+		return fmt.Errorf("timed out after %s waiting for '%s' to become stale", timeout, s.selectors)
+	}
+	return nil
+}
+
+// WaitUntilTextEquals waits until the text of exactly one element that the
+// selection refers to equals the expected text. An element that is not yet
+// present is treated as not matching. If the timeout elapses first, the
+// returned error includes the last text that was seen.
+func (s *Selection) WaitUntilTextEquals(expected string, timeout time.Duration) error {
+	lastText, matched := s.waitForText(timeout, func(text string) bool {
+		return text == expected
+	})
+
+	if !matched {
+		return fmt.Errorf("timed out after %s waiting for '%s' text to equal '%s' (last: '%s')", timeout, s.selectors, expected, lastText)
+	}
+	return nil
+}
+
+// WaitUntilText is equivalent to WaitUntilTextEquals.
+func (s *Selection) WaitUntilText(expected string, timeout time.Duration) error {
+	return s.WaitUntilTextEquals(expected, timeout)
+}
+
+// waitForText polls the text of the selection until it satisfies the provided
+// condition or the timeout elapses. It returns the last text that was read.
+func (s *Selection) waitForText(timeout time.Duration, condition func(text string) bool) (lastText string, matched bool) {
+	matched = s.waitFor(timeout, func() bool {
+		text, err := s.Text()
+		if err != nil {
+			return false
+		}
+		lastText = text
+		return condition(text)
+	})
+	return lastText, matched
+}
+
+// WaitUntilTextContains waits until the text of exactly one element that the
+// selection refers to contains the provided substring. An element that is not
+// yet present is treated as not matching. If the timeout elapses first, the
+// returned error includes the last text that was seen.
+func (s *Selection) WaitUntilTextContains(substring string, timeout time.Duration) error {
+	lastText, matched := s.waitForText(timeout, func(text string) bool {
+		return strings.Contains(text, substring)
+	})
+
+	if !matched {
+		return fmt.Errorf("timed out after %s waiting for '%s' text to contain '%s' (last: '%s')", timeout, s.selectors, substring, lastText)
+	}
+	return nil
+}
+
+// WaitUntilAttributeEquals waits until the named attribute of exactly one
+// element that the selection refers to equals the provided value, ex.
+//    menuButton.WaitUntilAttributeEquals("aria-expanded", "true", time.Second)
+// An element that is not yet present is treated as not matching. If the timeout
+// elapses first, the returned error includes the last attribute value that was
+// seen.
+func (s *Selection) WaitUntilAttributeEquals(name, value string, timeout time.Duration) error {
+	var lastValue string
+	matched := s.waitFor(timeout, func() bool {
+		attributeValue, err := s.Attribute(name)
+		if err != nil {
+			return false
+		}
+		lastValue = attributeValue
+		return attributeValue == value
+	})
+
+	if !matched {
+		return fmt.Errorf("timed out after %s waiting for '%s' attribute '%s' to equal '%s' (last: '%s')", timeout, s.selectors, name, value, lastValue)
+	}
+	return nil
+}
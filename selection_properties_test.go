@@ -2,6 +2,7 @@ package agouti_test
 
 import (
 	"errors"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -27,6 +28,7 @@ var _ = Describe("Selection Properties", func() {
 		secondElement = &mocks.Element{}
 		elementRepository = &mocks.ElementRepository{}
 		selection = NewTestMultiSelection(session, elementRepository, "#selector")
+		UseFakeClock(selection)
 	})
 
 	Describe("#Text", func() {
@@ -54,6 +56,52 @@ var _ = Describe("Selection Properties", func() {
 				Expect(err).To(MatchError("failed to retrieve text for selection 'CSS: #selector': some error"))
 			})
 		})
+
+		Context("when the element is stale when its text is first retrieved", func() {
+			var staleElement *staleOnceElement
+
+			BeforeEach(func() {
+				staleElement = &staleOnceElement{Element: firstElement}
+				elementRepository.GetExactlyOneCall.ReturnElement = staleElement
+			})
+
+			It("should select the element again and successfully return the text", func() {
+				firstElement.GetTextCall.ReturnText = "some text"
+				Expect(selection.Text()).To(Equal("some text"))
+				Expect(staleElement.calls).To(Equal(2))
+			})
+
+			It("should only retry once", func() {
+				firstElement.GetTextCall.Err = &api.ResponseError{Code: "stale element reference", Message: "some error"}
+				_, err := selection.Text()
+				Expect(err).To(MatchError("failed to retrieve text for selection 'CSS: #selector': request unsuccessful: some error"))
+				Expect(staleElement.calls).To(Equal(2))
+			})
+
+			It("should retry as many times as the stale element retry setting allows", func() {
+				WithStaleElementRetries(selection, 3)
+				firstElement.GetTextCall.Err = &api.ResponseError{Code: "stale element reference", Message: "some error"}
+				selection.Text()
+				Expect(staleElement.calls).To(Equal(4))
+			})
+
+			It("should not retry when stale element retries are disabled", func() {
+				WithStaleElementRetries(selection, 0)
+				_, err := selection.Text()
+				Expect(err).To(MatchError("failed to retrieve text for selection 'CSS: #selector': request unsuccessful: element is not attached to the page document"))
+				Expect(staleElement.calls).To(Equal(1))
+			})
+		})
+
+		Context("when retrieving the text fails for a reason other than staleness", func() {
+			It("should not retry", func() {
+				staleElement := &staleOnceElement{Element: firstElement, err: errors.New("some error")}
+				elementRepository.GetExactlyOneCall.ReturnElement = staleElement
+				_, err := selection.Text()
+				Expect(err).To(MatchError("failed to retrieve text for selection 'CSS: #selector': some error"))
+				Expect(staleElement.calls).To(Equal(1))
+			})
+		})
 	})
 
 	Describe("#Active", func() {
@@ -139,6 +187,61 @@ var _ = Describe("Selection Properties", func() {
 				Expect(err).To(MatchError("failed to retrieve attribute value for selection 'CSS: #selector': some error"))
 			})
 		})
+
+		Context("when the element is stale when its attribute is first retrieved", func() {
+			It("should select the element again and successfully return the attribute value", func() {
+				staleElement := &staleOnceElement{Element: firstElement}
+				elementRepository.GetExactlyOneCall.ReturnElement = staleElement
+				firstElement.GetAttributeCall.ReturnValue = "some value"
+				Expect(selection.Attribute("some-attribute")).To(Equal("some value"))
+				Expect(staleElement.calls).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("#AttributesMap", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully return the value of each attribute keyed by name", func() {
+			firstElement.GetAttributeCall.ReturnValue = "some value"
+			Expect(selection.AttributesMap("href", "target")).To(Equal(map[string]string{
+				"href":   "some value",
+				"target": "some value",
+			}))
+			Expect(firstElement.GetAttributeCall.Attribute).To(Equal("target"))
+		})
+
+		It("should select the element only once", func() {
+			countingRepository := &countingElementRepository{ElementRepository: elementRepository}
+			selection = NewTestMultiSelection(session, countingRepository, "#selector")
+			_, err := selection.AttributesMap("href", "target", "rel")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(countingRepository.exactlyOneCalls).To(Equal(1))
+		})
+
+		Context("when no attributes are provided", func() {
+			It("should return an empty map", func() {
+				Expect(selection.AttributesMap()).To(BeEmpty())
+			})
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.AttributesMap("href")
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when retrieving an attribute fails", func() {
+			It("should return an error", func() {
+				firstElement.GetAttributeCall.Err = errors.New("some error")
+				_, err := selection.AttributesMap("href")
+				Expect(err).To(MatchError("failed to retrieve attributes for selection 'CSS: #selector': some error"))
+			})
+		})
 	})
 
 	Describe("#CSS", func() {
@@ -172,6 +275,92 @@ var _ = Describe("Selection Properties", func() {
 				Expect(err).To(MatchError("failed to retrieve CSS property value for selection 'CSS: #selector': some error"))
 			})
 		})
+
+		Context("when the element is stale when its CSS property is first retrieved", func() {
+			It("should select the element again and successfully return the property value", func() {
+				staleElement := &staleOnceElement{Element: firstElement}
+				elementRepository.GetExactlyOneCall.ReturnElement = staleElement
+				firstElement.GetCSSCall.ReturnValue = "some value"
+				Expect(selection.CSS("some-property")).To(Equal("some value"))
+				Expect(staleElement.calls).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("#Value", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully return the live value property", func() {
+			firstElement.GetPropertyCall.ReturnValue = "42"
+			Expect(selection.Value()).To(Equal("42"))
+			Expect(firstElement.GetPropertyCall.Property).To(Equal("value"))
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.Value()
+				Expect(err).To(MatchError("failed to read value of 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the session fails to retrieve the value property", func() {
+			It("should return an error", func() {
+				firstElement.GetPropertyCall.Err = errors.New("some error")
+				_, err := selection.Value()
+				Expect(err).To(MatchError("failed to read value of 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the element is stale when its value is first retrieved", func() {
+			It("should select the element again and successfully return the value", func() {
+				staleElement := &staleOnceElement{Element: firstElement}
+				elementRepository.GetExactlyOneCall.ReturnElement = staleElement
+				firstElement.GetPropertyCall.ReturnValue = "42"
+				Expect(selection.Value()).To(Equal("42"))
+				Expect(staleElement.calls).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("#HasClass", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should request the class attribute", func() {
+			_, err := selection.HasClass("some-class")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(firstElement.GetAttributeCall.Attribute).To(Equal("class"))
+		})
+
+		It("should successfully return true when the element has the class", func() {
+			firstElement.GetAttributeCall.ReturnValue = "other-class\tsome-class  another-class"
+			Expect(selection.HasClass("some-class")).To(BeTrue())
+		})
+
+		It("should successfully return false when the element does not have the class", func() {
+			firstElement.GetAttributeCall.ReturnValue = "some-class-suffix other-class"
+			Expect(selection.HasClass("some-class")).To(BeFalse())
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.HasClass("some-class")
+				Expect(err).To(MatchError("failed to determine classes for 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the session fails to retrieve the class attribute", func() {
+			It("should return an error", func() {
+				firstElement.GetAttributeCall.Err = errors.New("some error")
+				_, err := selection.HasClass("some-class")
+				Expect(err).To(MatchError("failed to determine classes for 'CSS: #selector': some error"))
+			})
+		})
 	})
 
 	Describe("#Selected", func() {
@@ -278,4 +467,435 @@ var _ = Describe("Selection Properties", func() {
 			})
 		})
 	})
+
+	Describe("#State", func() {
+		BeforeEach(func() {
+			firstElement.GetIDCall.ReturnText = "some-id"
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully return the state of the element", func() {
+			session.ExecuteCall.Result = `{"displayed": true, "enabled": false, "selected": true}`
+			Expect(selection.State()).To(Equal(ElementState{Displayed: true, Enabled: false, Selected: true}))
+		})
+
+		It("should read the state using a single script call against the element", func() {
+			selection.State()
+			Expect(session.ExecuteCall.Body).To(ContainSubstring("enabled: !element.disabled"))
+			Expect(session.ExecuteCall.Arguments).To(Equal([]interface{}{map[string]string{
+				"ELEMENT":                             "some-id",
+				"element-6066-11e4-a52e-4f735466cecf": "some-id",
+			}}))
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.State()
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err := selection.State()
+				Expect(err).To(MatchError("failed to retrieve state of selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#AccessibleName", func() {
+		BeforeEach(func() {
+			firstElement.GetIDCall.ReturnText = "some-id"
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should return the label computed by the browser", func() {
+			firstElement.GetComputedLabelCall.ReturnLabel = "Close dialog"
+			Expect(selection.AccessibleName()).To(Equal("Close dialog"))
+			Expect(session.ExecuteCall.Body).To(BeEmpty())
+		})
+
+		Context("when the WebDriver does not support computed labels", func() {
+			BeforeEach(func() {
+				firstElement.GetComputedLabelCall.Err = errors.New("unknown command: computedlabel")
+			})
+
+			It("should compute the name using JavaScript", func() {
+				session.ExecuteCall.Result = `"Close dialog"`
+				Expect(selection.AccessibleName()).To(Equal("Close dialog"))
+				Expect(session.ExecuteCall.Body).To(ContainSubstring(`element.getAttribute("aria-label")`))
+				Expect(session.ExecuteCall.Arguments).To(Equal([]interface{}{
+					map[string]string{
+						"ELEMENT":                             "some-id",
+						"element-6066-11e4-a52e-4f735466cecf": "some-id",
+					},
+				}))
+			})
+
+			Context("when the script fails", func() {
+				It("should return an error", func() {
+					session.ExecuteCall.Err = errors.New("some error")
+					_, err := selection.AccessibleName()
+					Expect(err).To(MatchError("failed to compute accessible name of 'CSS: #selector': some error"))
+				})
+			})
+		})
+
+		Context("when retrieving the computed label fails", func() {
+			It("should return an error without running the script", func() {
+				firstElement.GetComputedLabelCall.Err = errors.New("some error")
+				_, err := selection.AccessibleName()
+				Expect(err).To(MatchError("failed to compute accessible name of 'CSS: #selector': some error"))
+				Expect(session.ExecuteCall.Body).To(BeEmpty())
+			})
+		})
+
+		Context("when the selection does not refer to exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.AccessibleName()
+				Expect(err).To(MatchError("failed to compute accessible name of 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#Rect", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		Context("when the WebDriver uses the W3C protocol", func() {
+			BeforeEach(func() {
+				session.ProtocolCall.ReturnProtocol = "w3c"
+			})
+
+			It("should return the element rect from a single request", func() {
+				firstElement.GetRectCall.ReturnX = 10
+				firstElement.GetRectCall.ReturnY = 20
+				firstElement.GetRectCall.ReturnWidth = 100
+				firstElement.GetRectCall.ReturnHeight = 50
+				x, y, width, height, err := selection.Rect()
+				Expect(err).NotTo(HaveOccurred())
+				Expect([]int{x, y, width, height}).To(Equal([]int{10, 20, 100, 50}))
+			})
+
+			Context("when retrieving the rect fails", func() {
+				It("should return an error", func() {
+					firstElement.GetRectCall.Err = errors.New("some error")
+					_, _, _, _, err := selection.Rect()
+					Expect(err).To(MatchError("failed to retrieve rect for selection 'CSS: #selector': some error"))
+				})
+			})
+		})
+
+		Context("when the WebDriver uses the JSON Wire Protocol", func() {
+			It("should combine the element location and size", func() {
+				firstElement.GetLocationCall.ReturnX = 10
+				firstElement.GetLocationCall.ReturnY = 20
+				firstElement.GetSizeCall.ReturnWidth = 100
+				firstElement.GetSizeCall.ReturnHeight = 50
+				x, y, width, height, err := selection.Rect()
+				Expect(err).NotTo(HaveOccurred())
+				Expect([]int{x, y, width, height}).To(Equal([]int{10, 20, 100, 50}))
+				Expect(firstElement.GetRectCall.Called).To(BeFalse())
+			})
+
+			Context("when retrieving the location fails", func() {
+				It("should return an error", func() {
+					firstElement.GetLocationCall.Err = errors.New("some error")
+					_, _, _, _, err := selection.Rect()
+					Expect(err).To(MatchError("failed to retrieve location for selection 'CSS: #selector': some error"))
+				})
+			})
+
+			Context("when retrieving the size fails", func() {
+				It("should return an error", func() {
+					firstElement.GetSizeCall.Err = errors.New("some error")
+					_, _, _, _, err := selection.Rect()
+					Expect(err).To(MatchError("failed to retrieve size for selection 'CSS: #selector': some error"))
+				})
+			})
+		})
+
+		Context("when the selection does not refer to exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, _, _, _, err := selection.Rect()
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#InViewport", func() {
+		BeforeEach(func() {
+			firstElement.GetIDCall.ReturnText = "some-id"
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should return whether the element is entirely within the viewport", func() {
+			session.ExecuteCall.Result = "true"
+			Expect(selection.InViewport(false)).To(BeTrue())
+			Expect(session.ExecuteCall.Body).To(ContainSubstring("getBoundingClientRect()"))
+			Expect(session.ExecuteCall.Arguments).To(Equal([]interface{}{
+				map[string]string{
+					"ELEMENT":                             "some-id",
+					"element-6066-11e4-a52e-4f735466cecf": "some-id",
+				},
+				false,
+			}))
+		})
+
+		It("should allow partial visibility to count", func() {
+			session.ExecuteCall.Result = "false"
+			Expect(selection.InViewport(true)).To(BeFalse())
+			Expect(session.ExecuteCall.Arguments[1]).To(BeTrue())
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.InViewport(false)
+				Expect(err).To(MatchError("failed to determine viewport visibility of 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the script fails", func() {
+			It("should return an error", func() {
+				session.ExecuteCall.Err = errors.New("some error")
+				_, err := selection.InViewport(false)
+				Expect(err).To(MatchError("failed to determine viewport visibility of 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#WaitUntilClickable", func() {
+		BeforeEach(func() {
+			elementRepository.GetAtLeastOneCall.ReturnElements = []element.Element{firstElement}
+		})
+
+		It("should successfully return when the elements are visible and enabled", func() {
+			firstElement.IsDisplayedCall.ReturnDisplayed = true
+			firstElement.IsEnabledCall.ReturnEnabled = true
+			Expect(selection.WaitUntilClickable(time.Second)).To(Succeed())
+		})
+
+		Context("when the elements are visible but not enabled", func() {
+			It("should return an error after the timeout", func() {
+				firstElement.IsDisplayedCall.ReturnDisplayed = true
+				err := selection.WaitUntilClickable(20 * time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for 'CSS: #selector' to be clickable"))
+			})
+		})
+
+		Context("when the elements are enabled but not visible", func() {
+			It("should return an error after the timeout", func() {
+				firstElement.IsEnabledCall.ReturnEnabled = true
+				err := selection.WaitUntilClickable(20 * time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for 'CSS: #selector' to be clickable"))
+			})
+		})
+
+		Context("when the elements cannot be selected", func() {
+			It("should return an error after the timeout", func() {
+				elementRepository.GetAtLeastOneCall.Err = errors.New("some error")
+				err := selection.WaitUntilClickable(20 * time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for 'CSS: #selector' to be clickable"))
+			})
+		})
+	})
+
+	Describe("#WaitUntilStale", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully return when the element becomes stale", func() {
+			firstElement.GetNameCall.Err = &api.ResponseError{Code: "stale element reference", Message: "element is not attached to the page document"}
+			Expect(selection.WaitUntilStale(time.Second)).To(Succeed())
+		})
+
+		Context("when the element is still attached after the timeout", func() {
+			It("should return an error", func() {
+				Expect(selection.WaitUntilStale(5 * time.Second)).To(MatchError("timed out after 5s waiting for 'CSS: #selector' to become stale"))
+			})
+		})
+
+		Context("when the element cannot be selected", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				Expect(selection.WaitUntilStale(time.Second)).To(MatchError("failed to select element from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when checking the element fails for a reason other than staleness", func() {
+			It("should return an error immediately", func() {
+				firstElement.GetNameCall.Err = errors.New("some error")
+				Expect(selection.WaitUntilStale(time.Hour)).To(MatchError("failed to determine whether 'CSS: #selector' is stale: some error"))
+			})
+		})
+	})
+
+	Describe("#WaitUntilTextEquals", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully return when the text equals the expected text", func() {
+			firstElement.GetTextCall.ReturnText = "3 items"
+			Expect(selection.WaitUntilTextEquals("3 items", time.Second)).To(Succeed())
+		})
+
+		It("should keep waiting while the text cannot be read", func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = &staleOnceElement{Element: firstElement, err: errors.New("some error")}
+			firstElement.GetTextCall.ReturnText = "3 items"
+			Expect(selection.WaitUntilTextEquals("3 items", time.Second)).To(Succeed())
+		})
+
+		Context("when the text does not equal the expected text before the timeout", func() {
+			It("should return an error including the last text", func() {
+				firstElement.GetTextCall.ReturnText = "2 items"
+				err := selection.WaitUntilTextEquals("3 items", 20*time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for 'CSS: #selector' text to equal '3 items' (last: '2 items')"))
+			})
+		})
+	})
+
+	Describe("#WaitUntilText", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully return when the text equals the expected text", func() {
+			firstElement.GetTextCall.ReturnText = "Done"
+			Expect(selection.WaitUntilText("Done", time.Second)).To(Succeed())
+		})
+
+		Context("when the text does not equal the expected text before the timeout", func() {
+			It("should return an error including the last text", func() {
+				firstElement.GetTextCall.ReturnText = "Processing"
+				err := selection.WaitUntilText("Done", 20*time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for 'CSS: #selector' text to equal 'Done' (last: 'Processing')"))
+			})
+		})
+
+		Context("when the element cannot be selected", func() {
+			It("should return an error after the timeout", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				err := selection.WaitUntilText("Done", 20*time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for 'CSS: #selector' text to equal 'Done' (last: '')"))
+			})
+		})
+	})
+
+	Describe("#WaitUntilTextContains", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully return when the text contains the substring", func() {
+			firstElement.GetTextCall.ReturnText = "build complete"
+			Expect(selection.WaitUntilTextContains("complete", time.Second)).To(Succeed())
+		})
+
+		Context("when the text does not contain the substring before the timeout", func() {
+			It("should return an error including the last text", func() {
+				firstElement.GetTextCall.ReturnText = "building"
+				err := selection.WaitUntilTextContains("complete", 20*time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for 'CSS: #selector' text to contain 'complete' (last: 'building')"))
+			})
+		})
+
+		Context("when the element cannot be selected", func() {
+			It("should return an error after the timeout", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				err := selection.WaitUntilTextContains("complete", 20*time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for 'CSS: #selector' text to contain 'complete' (last: '')"))
+			})
+		})
+	})
+
+	Describe("#WaitUntilAttributeEquals", func() {
+		BeforeEach(func() {
+			elementRepository.GetExactlyOneCall.ReturnElement = firstElement
+		})
+
+		It("should successfully return when the attribute equals the value", func() {
+			firstElement.GetAttributeCall.ReturnValue = "true"
+			Expect(selection.WaitUntilAttributeEquals("aria-expanded", "true", time.Second)).To(Succeed())
+			Expect(firstElement.GetAttributeCall.Attribute).To(Equal("aria-expanded"))
+		})
+
+		Context("when the attribute does not equal the value before the timeout", func() {
+			It("should return an error including the last attribute value", func() {
+				firstElement.GetAttributeCall.ReturnValue = "false"
+				err := selection.WaitUntilAttributeEquals("aria-expanded", "true", 20*time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for 'CSS: #selector' attribute 'aria-expanded' to equal 'true' (last: 'false')"))
+			})
+		})
+
+		Context("when the element cannot be selected", func() {
+			It("should return an error after the timeout", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				err := selection.WaitUntilAttributeEquals("aria-expanded", "true", 20*time.Millisecond)
+				Expect(err).To(MatchError("timed out after 20ms waiting for 'CSS: #selector' attribute 'aria-expanded' to equal 'true' (last: '')"))
+			})
+		})
+	})
 })
+
+type staleOnceElement struct {
+	*mocks.Element
+	err   error
+	calls int
+}
+
+func (e *staleOnceElement) firstCall() error {
+	e.calls++
+	if e.calls > 1 {
+		return nil
+	}
+	if e.err != nil {
+		return e.err
+	}
+	return &api.ResponseError{Code: "stale element reference", Message: "element is not attached to the page document"}
+}
+
+func (e *staleOnceElement) GetText() (string, error) {
+	if err := e.firstCall(); err != nil {
+		return "", err
+	}
+	return e.Element.GetText()
+}
+
+func (e *staleOnceElement) GetAttribute(attribute string) (string, error) {
+	if err := e.firstCall(); err != nil {
+		return "", err
+	}
+	return e.Element.GetAttribute(attribute)
+}
+
+func (e *staleOnceElement) GetProperty(property string) (string, error) {
+	if err := e.firstCall(); err != nil {
+		return "", err
+	}
+	return e.Element.GetProperty(property)
+}
+
+func (e *staleOnceElement) GetCSS(property string) (string, error) {
+	if err := e.firstCall(); err != nil {
+		return "", err
+	}
+	return e.Element.GetCSS(property)
+}
+
+type countingElementRepository struct {
+	*mocks.ElementRepository
+	exactlyOneCalls int
+}
+
+func (r *countingElementRepository) GetExactlyOne() (element.Element, error) {
+	r.exactlyOneCalls++
+	return r.ElementRepository.GetExactlyOne()
+}
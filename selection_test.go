@@ -30,6 +30,22 @@ var _ = Describe("Selection", func() {
 		})
 	})
 
+	Describe("#Clone", func() {
+		It("should return a selection with the same selectors", func() {
+			selection := NewTestMultiSelection(nil, nil, "#selector").AllByXPath("#subselector")
+			Expect(selection.Clone().String()).To(Equal("selection 'CSS: #selector | XPath: #subselector'"))
+		})
+
+		It("should allow a base selection to be branched without affecting the base or other branches", func() {
+			base := NewTestMultiSelection(nil, nil, "#selector").All("#base")
+			first := base.Clone().Find("#x")
+			second := base.Clone().Find("#y")
+			Expect(first.String()).To(Equal("selection 'CSS: #selector #base #x [single]'"))
+			Expect(second.String()).To(Equal("selection 'CSS: #selector #base #y [single]'"))
+			Expect(base.String()).To(Equal("selection 'CSS: #selector #base'"))
+		})
+	})
+
 	Describe("#Elements", func() {
 		var (
 			selection         *Selection
@@ -81,6 +97,165 @@ var _ = Describe("Selection", func() {
 		})
 	})
 
+	Describe("#CountOrZero", func() {
+		var (
+			selection         *MultiSelection
+			elementRepository *mocks.ElementRepository
+		)
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			selection = NewTestMultiSelection(nil, elementRepository, "#selector")
+			elementRepository.GetCall.ReturnElements = []element.Element{firstElement, secondElement}
+		})
+
+		It("should successfully return the number of elements", func() {
+			Expect(selection.CountOrZero()).To(Equal(2))
+		})
+
+		Context("when the selection has no selectors", func() {
+			It("should successfully return zero", func() {
+				Expect((&Selection{}).CountOrZero()).To(Equal(0))
+			})
+		})
+
+		Context("when the the session fails to retrieve the elements", func() {
+			It("should return an error", func() {
+				elementRepository.GetCall.Err = errors.New("some error")
+				_, err := selection.CountOrZero()
+				Expect(err).To(MatchError("failed to select elements from selection 'CSS: #selector': some error"))
+			})
+		})
+	})
+
+	Describe("#IsPresent", func() {
+		var (
+			selection         *MultiSelection
+			elementRepository *mocks.ElementRepository
+		)
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			selection = NewTestMultiSelection(nil, elementRepository, "#selector")
+		})
+
+		It("should successfully return true when there are elements", func() {
+			elementRepository.GetCall.ReturnElements = []element.Element{firstElement, secondElement}
+			Expect(selection.IsPresent()).To(BeTrue())
+		})
+
+		It("should successfully return false when there are no elements", func() {
+			elementRepository.GetCall.ReturnElements = []element.Element{}
+			Expect(selection.IsPresent()).To(BeFalse())
+		})
+
+		Context("when a single element is not found", func() {
+			It("should successfully return false", func() {
+				elementRepository.GetCall.Err = element.ErrNotFound
+				Expect(selection.IsPresent()).To(BeFalse())
+			})
+		})
+
+		Context("when an indexed element is out of range", func() {
+			It("should successfully return false", func() {
+				elementRepository.GetCall.Err = errors.New("element index out of range")
+				Expect(selection.IsPresent()).To(BeFalse())
+			})
+		})
+
+		Context("when the session fails to retrieve the elements", func() {
+			It("should return an error", func() {
+				elementRepository.GetCall.Err = errors.New("some error")
+				_, err := selection.IsPresent()
+				Expect(err).To(MatchError("failed to select elements from selection 'CSS: #selector': some error"))
+			})
+		})
+
+		Context("when the WebDriver cannot locate the first element", func() {
+			It("should successfully return false", func() {
+				session := &mocks.Session{}
+				session.GetElementCall.Err = &api.ResponseError{Code: "no such element", Message: "Unable to locate element"}
+				present, err := NewTestPage(session).First("#missing").IsPresent()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(present).To(BeFalse())
+				Expect(session.GetElementCall.Selector).To(Equal(api.Selector{Using: "css selector", Value: "#missing"}))
+			})
+		})
+	})
+
+	Describe("#Snapshot", func() {
+		var (
+			selection         *Selection
+			elementRepository *mocks.ElementRepository
+		)
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			selection = NewTestSelection(nil, elementRepository, "#selector")
+		})
+
+		It("should return a selection backed by the elements retrieved once", func() {
+			elementRepository.GetCall.ReturnElements = []element.Element{firstElement, secondElement}
+			snapshot, err := selection.Snapshot()
+			Expect(err).NotTo(HaveOccurred())
+			elementRepository.GetCall.ReturnElements = []element.Element{firstElement}
+			Expect(snapshot.Count()).To(Equal(2))
+		})
+
+		It("should describe the same selection", func() {
+			snapshot, err := selection.Snapshot()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshot.String()).To(Equal("selection 'CSS: #selector [single]'"))
+		})
+
+		Context("when the element repository fails to return the elements", func() {
+			It("should return an error", func() {
+				elementRepository.GetCall.Err = errors.New("some error")
+				_, err := selection.Snapshot()
+				Expect(err).To(MatchError("failed to select elements from selection 'CSS: #selector [single]': some error"))
+			})
+		})
+	})
+
+	Describe("#ShadowRoot", func() {
+		var (
+			selection         *Selection
+			elementRepository *mocks.ElementRepository
+			element           *mocks.Element
+		)
+
+		BeforeEach(func() {
+			elementRepository = &mocks.ElementRepository{}
+			selection = NewTestSelection(nil, elementRepository, "#selector")
+			element = &mocks.Element{}
+			element.GetShadowRootCall.ReturnShadowRoot = &api.ShadowRoot{ID: "some-id"}
+			elementRepository.GetExactlyOneCall.ReturnElement = element
+		})
+
+		It("should return a selection scoped to the shadow root", func() {
+			shadowRoot, err := selection.ShadowRoot()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shadowRoot.String()).To(Equal("selection 'CSS: #selector [single] | Shadow Root'"))
+			Expect(shadowRoot.Find("button").String()).To(Equal("selection 'CSS: #selector [single] | Shadow Root | CSS: button [single]'"))
+		})
+
+		Context("when the element repository fails to return exactly one element", func() {
+			It("should return an error", func() {
+				elementRepository.GetExactlyOneCall.Err = errors.New("some error")
+				_, err := selection.ShadowRoot()
+				Expect(err).To(MatchError("failed to select element from selection 'CSS: #selector [single]': some error"))
+			})
+		})
+
+		Context("when the element has no shadow root", func() {
+			It("should return an error", func() {
+				element.GetShadowRootCall.Err = errors.New("element has no shadow root")
+				_, err := selection.ShadowRoot()
+				Expect(err).To(MatchError("failed to retrieve shadow root of selection 'CSS: #selector [single]': element has no shadow root"))
+			})
+		})
+	})
+
 	Describe("#EqualsElement", func() {
 		var (
 			firstSelection          *Selection
@@ -0,0 +1,134 @@
+package agouti
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	// defaultPollInterval is the interval at which waiting methods check
+	// whether their condition has been met.
+	defaultPollInterval = 100 * time.Millisecond
+
+	// minPollInterval is the shortest interval accepted by Page.SetPollInterval.
+	minPollInterval = 10 * time.Millisecond
+
+	// defaultStaleElementRetries is the number of times a value is re-read
+	// from a freshly selected element when the selected element goes stale.
+	defaultStaleElementRetries = 1
+)
+
+// waitSettings are shared by a page and all selections created from it.
+type waitSettings struct {
+	pollInterval        time.Duration
+	stabilityTimeout    time.Duration
+	staleElementRetries int
+	clock               clock
+}
+
+func newWaitSettings() *waitSettings {
+	return &waitSettings{staleElementRetries: defaultStaleElementRetries}
+}
+
+// defaultWaits are used by Retry, which is not tied to a page.
+var defaultWaits = newWaitSettings()
+
+// clock provides the current time and timers to the waiting methods, so that
+// tests may replace real time with a fake clock.
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (s *selectable) clock() clock {
+	if s.waits == nil || s.waits.clock == nil {
+		return realClock{}
+	}
+	return s.waits.clock
+}
+
+func (s *selectable) pollInterval() time.Duration {
+	if s.waits == nil || s.waits.pollInterval == 0 {
+		return defaultPollInterval
+	}
+	return s.waits.pollInterval
+}
+
+func (s *selectable) stabilityTimeout() time.Duration {
+	if s.waits == nil {
+		return 0
+	}
+	return s.waits.stabilityTimeout
+}
+
+func (s *selectable) staleElementRetries() int {
+	if s.waits == nil {
+		return defaultStaleElementRetries
+	}
+	return s.waits.staleElementRetries
+}
+
+// waitFor checks the provided condition every poll interval until it returns
+// true or the timeout elapses. It returns whether the condition was met.
+func (s *selectable) waitFor(timeout time.Duration, condition func() bool) bool {
+	clock := s.clock()
+	interval := s.pollInterval()
+	deadline := clock.Now().Add(timeout)
+	for {
+		if condition() {
+			return true
+		}
+
+		remaining := deadline.Sub(clock.Now())
+		if remaining <= 0 {
+			return false
+		}
+		if remaining < interval {
+			interval = remaining
+		}
+		<-clock.After(interval)
+	}
+}
+
+// Retry calls fn up to the provided number of attempts, waiting one poll
+// interval between attempts. It returns nil as soon as an attempt succeeds, or
+// the error returned by the last attempt, ex.
+//    err := agouti.Retry(3, func() error {
+//        return page.Find("#menu").Click()
+//    })
+// Use Page.Retry to wait using the page's poll interval and to log each failed
+// attempt to the page's trace logger.
+func Retry(attempts int, fn func() error) error {
+	return (&selectable{waits: defaultWaits}).retry(attempts, fn, nil)
+}
+
+func (s *selectable) retry(attempts int, fn func() error, logger io.Writer) error {
+	if attempts < 1 {
+		return fmt.Errorf("invalid number of attempts: %d", attempts)
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if logger != nil {
+			fmt.Fprintf(logger, "attempt %d of %d failed: %s\n", attempt, attempts, err)
+		}
+		if attempt < attempts {
+			<-s.clock().After(s.pollInterval())
+		}
+	}
+	return err
+}
@@ -0,0 +1,39 @@
+package agouti_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/sclevine/agouti"
+)
+
+var _ = Describe("Retry", func() {
+	var restoreClock func()
+
+	BeforeEach(func() {
+		restoreClock = UseFakeDefaultClock()
+	})
+
+	AfterEach(func() {
+		restoreClock()
+	})
+
+	It("should call the function until it succeeds", func() {
+		attempts := 0
+		Expect(Retry(3, func() error {
+			attempts++
+			if attempts < 2 {
+				return errors.New("some error")
+			}
+			return nil
+		})).To(Succeed())
+		Expect(attempts).To(Equal(2))
+	})
+
+	It("should return the error from the last attempt when every attempt fails", func() {
+		Expect(Retry(2, func() error {
+			return errors.New("some error")
+		})).To(MatchError("some error"))
+	})
+})
@@ -59,7 +59,11 @@ func NewWebDriver(url string, command []string, options ...Option) *WebDriver {
 // http.DefaultClient if none was provided.
 func (w *WebDriver) NewPage(options ...Option) (*Page, error) {
 	newOptions := w.defaultOptions.Merge(options)
-	session, err := w.Open(newOptions.Capabilities())
+	capabilities := newOptions.Capabilities()
+	if err := capabilities.validate(); err != nil {
+		return nil, fmt.Errorf("invalid capabilities: %s", err)
+	}
+	session, err := w.Open(capabilities)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to WebDriver: %s", err)
 	}